@@ -110,6 +110,8 @@ func setAppropriateNetworkSubConfig(band *utils.LTEBand, config *models.NetworkC
 			Earfcnul: earfcnul,
 		}
 		return config, nil
+	case utils.SDLMode:
+		return nil, fmt.Errorf("Not a FDD or TDD Band: %d is a supplemental downlink (SDL) band", band.ID)
 	default:
 		return nil, fmt.Errorf("Invalid LTE band mode supplied")
 	}
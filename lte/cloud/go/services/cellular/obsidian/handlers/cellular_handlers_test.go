@@ -216,6 +216,23 @@ func TestSetBadOldConfigs(t *testing.T) {
 	}
 	status, _, err := obsidian_test.RunTest(t, setConfigTestCase)
 	assert.Equal(t, 400, status)
+
+	// Fail on a supplemental downlink band, which has no TDD/FDD sub-config
+	config.Ran.Earfcndl = 10120
+	protos.FillIn(config, swaggerConfig)
+	marshaledCfg, err = swaggerConfig.MarshalBinary()
+	assert.NoError(t, err)
+
+	setConfigTestCase = obsidian_test.Testcase{
+		Name:                     "Set SDL Earfcndl Config",
+		Method:                   "POST",
+		Url:                      fmt.Sprintf("%s/%s/configs/cellular", testUrlRoot, networkId),
+		Payload:                  string(marshaledCfg),
+		Expected:                 `{"message":"Error converting config model: Not a FDD or TDD Band: 32 is a supplemental downlink (SDL) band"}`,
+		Expect_http_error_status: true,
+	}
+	status, _, err = obsidian_test.RunTest(t, setConfigTestCase)
+	assert.Equal(t, 400, status)
 }
 
 func TestGetGatewayConfigs(t *testing.T) {
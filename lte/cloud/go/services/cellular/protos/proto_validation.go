@@ -87,6 +87,10 @@ func validateNetworkRANConfig(config *NetworkRANConfig) error {
 	if err != nil {
 		return err
 	}
+	// A supplemental downlink band has no uplink and cannot anchor a cell
+	if band.Mode == utils.SDLMode {
+		return fmt.Errorf("Not a FDD or TDD Band: %d is a supplemental downlink (SDL) band", band.ID)
+	}
 
 	if err := validateFDDConfig(earfcnDl, band, config.FddConfig); err != nil {
 		return err
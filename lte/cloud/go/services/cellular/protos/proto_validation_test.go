@@ -162,6 +162,8 @@ func TestValidateNetworkTDDRANConfig(t *testing.T) {
 		{600, "Not a TDD Band: 2"},
 		{1200, "Not a TDD Band: 3"},
 		{-1, "Invalid EARFCNDL: no matching band"},
+		// SDL EARFCNDLs
+		{10120, "Not a FDD or TDD Band: 32 is a supplemental downlink (SDL) band"},
 	}
 
 	config.Ran.TddConfig = &protos.NetworkRANConfig_TDDConfig{
@@ -195,6 +197,9 @@ func TestValidateNetworkFDDRANConfig(t *testing.T) {
 		{0, 18000},
 		{1, 0},
 		{1, 18001},
+		// EARFCNs above 65535
+		{66000, 0},
+		{66000, 131971},
 	}
 
 	negativeTestCases := []struct {
@@ -205,6 +210,8 @@ func TestValidateNetworkFDDRANConfig(t *testing.T) {
 		{0, 17999, "EARFCNUL=17999 invalid for Band 1 (18000, 18600)"},
 		{0, 18600, "EARFCNUL=18600 invalid for Band 1 (18000, 18600)"},
 		{43950, 43950, "Not a FDD Band: 43"},
+		{66000, 131972, "EARFCNUL=131972 invalid for Band 65 (131072, 131972)"},
+		{10120, 0, "Not a FDD or TDD Band: 32 is a supplemental downlink (SDL) band"},
 	}
 
 	config.Ran.TddConfig = nil
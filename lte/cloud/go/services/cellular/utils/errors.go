@@ -0,0 +1,24 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import "errors"
+
+// Errors returned by this package wrap one of the following, so that callers
+// can use errors.Is instead of matching on error messages.
+var (
+	// ErrNoMatchingBand is returned when a channel number or frequency does
+	// not belong to any supported band
+	ErrNoMatchingBand = errors.New("no matching band")
+	// ErrUnknownBand is returned when a band number has no definition
+	ErrUnknownBand = errors.New("no matching definition")
+	// ErrInvalidBandwidth is returned for a bandwidth that is not a supported
+	// LTE channel bandwidth
+	ErrInvalidBandwidth = errors.New("unsupported channel bandwidth")
+)
@@ -0,0 +1,55 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+var bandTableCSVHeader = []string{
+	"id", "name", "duplex", "earfcndl_min", "earfcndl_max",
+	"dl_low_mhz", "dl_high_mhz", "ul_low_mhz", "ul_high_mhz",
+}
+
+// WriteBandTableCSV writes the band table returned by ListBands as CSV, one
+// row per band. The uplink columns are empty for SDL bands.
+func WriteBandTableCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(bandTableCSVHeader); err != nil {
+		return err
+	}
+
+	formatFreq := func(freqMHz float64) string {
+		return strconv.FormatFloat(freqMHz, 'f', -1, 64)
+	}
+	for _, band := range ListBands() {
+		ranges := band.FreqRanges()
+		row := []string{
+			strconv.Itoa(int(band.ID)),
+			band.Name,
+			band.Mode.String(),
+			strconv.Itoa(int(band.StartEarfcnDl)),
+			strconv.Itoa(int(band.StartEarfcnDl + band.CountEarfcn - 1)),
+			formatFreq(ranges.DLLow),
+			formatFreq(ranges.DLHigh),
+			"",
+			"",
+		}
+		if band.Mode != SDLMode {
+			row[7], row[8] = formatFreq(ranges.ULLow), formatFreq(ranges.ULHigh)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,32 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteBandTableCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, utils.WriteBandTableCSV(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, len(utils.ListBands())+1)
+	assert.Equal(t, "id,name,duplex,earfcndl_min,earfcndl_max,dl_low_mhz,dl_high_mhz,ul_low_mhz,ul_high_mhz", lines[0])
+	assert.Contains(t, lines, "1,IMT 2100,FDD,0,599,2110,2170,1920,1980")
+	assert.Contains(t, lines, "32,L-Band,SDL,9920,10359,1452,1496,,")
+	assert.Contains(t, lines, "38,Band 38,TDD,37750,38249,2570,2620,2570,2620")
+	assert.Contains(t, lines, "40,TDD 2300,TDD,38650,39649,2300,2400,2300,2400")
+}
@@ -0,0 +1,147 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// BandInfo is the wire representation of a band for the config service APIs
+// and the format of band tables accepted by LoadBandTable. EARFCN ranges are
+// inclusive on both ends, frequencies are in MHz. The uplink EARFCN range is
+// only set for FDD bands. The name, the NB-IoT/LTE-M capabilities and the
+// transmit power cap are optional.
+type BandInfo struct {
+	ID          int32   `json:"id"`
+	Name        string  `json:"name"`
+	Duplex      string  `json:"duplex"`
+	EarfcnDlMin int32   `json:"earfcndl_min"`
+	EarfcnDlMax int32   `json:"earfcndl_max"`
+	EarfcnUlMin int32   `json:"earfcnul_min,omitempty"`
+	EarfcnUlMax int32   `json:"earfcnul_max,omitempty"`
+	DLLowMHz    float64 `json:"dl_low_mhz"`
+	DLHighMHz   float64 `json:"dl_high_mhz"`
+	ULLowMHz    float64 `json:"ul_low_mhz,omitempty"`
+	ULHighMHz   float64 `json:"ul_high_mhz,omitempty"`
+	NBIoT       bool    `json:"nb_iot"`
+	LTEM        bool    `json:"lte_m"`
+	MaxTxPower  float64 `json:"max_tx_power_dbm,omitempty"`
+}
+
+// ToBandInfo converts a band to its wire representation
+func (band LTEBand) ToBandInfo() *BandInfo {
+	ranges := band.FreqRanges()
+	info := &BandInfo{
+		ID:          band.ID,
+		Name:        band.Name,
+		Duplex:      band.Mode.String(),
+		EarfcnDlMin: band.StartEarfcnDl,
+		EarfcnDlMax: band.StartEarfcnDl + band.CountEarfcn - 1,
+		DLLowMHz:    ranges.DLLow,
+		DLHighMHz:   ranges.DLHigh,
+		ULLowMHz:    ranges.ULLow,
+		ULHighMHz:   ranges.ULHigh,
+		NBIoT:       band.NBIoT,
+		LTEM:        band.LTEM,
+		MaxTxPower:  band.MaxTxPowerDBm,
+	}
+	if band.Mode == FDDMode {
+		info.EarfcnUlMin = band.StartEarfcnUl
+		info.EarfcnUlMax = band.StartEarfcnUl + band.CountEarfcn - 1
+	}
+	return info
+}
+
+// BandFromInfo converts the wire representation of a band back to a band,
+// validating it. The upper edges of the frequency ranges are optional since
+// they follow from the EARFCN ranges, but must match them when set. TDD bands
+// may repeat their downlink range as the uplink range, SDL bands have none.
+func BandFromInfo(info *BandInfo) (*LTEBand, error) {
+	if info.ID <= 0 {
+		return nil, fmt.Errorf("Band %d: band number must be positive", info.ID)
+	}
+	mode, err := parseDuplexMode(info.Duplex)
+	if err != nil {
+		return nil, fmt.Errorf("Band %d: %s", info.ID, err)
+	}
+	if info.EarfcnDlMin < 0 || info.EarfcnDlMax < info.EarfcnDlMin || info.EarfcnDlMax > maxEARFCN {
+		return nil, fmt.Errorf("Band %d: invalid EARFCNDL range %d-%d", info.ID, info.EarfcnDlMin, info.EarfcnDlMax)
+	}
+	if !isFinite(info.DLLowMHz) || info.DLLowMHz <= 0 {
+		return nil, fmt.Errorf("Band %d: FDL_low must be a finite positive frequency", info.ID)
+	}
+	if !isFinite(info.MaxTxPower) || info.MaxTxPower < 0 || info.MaxTxPower > maxTxPowerDBm {
+		return nil, fmt.Errorf("Band %d: transmit power cap must be between 0 and %d dBm", info.ID, maxTxPowerDBm)
+	}
+
+	band := &LTEBand{
+		ID:            info.ID,
+		Name:          info.Name,
+		Mode:          mode,
+		StartEarfcnDl: info.EarfcnDlMin,
+		CountEarfcn:   info.EarfcnDlMax - info.EarfcnDlMin + 1,
+		StartFreqDl:   info.DLLowMHz,
+		NBIoT:         info.NBIoT,
+		LTEM:          info.LTEM,
+		MaxTxPowerDBm: info.MaxTxPower,
+	}
+	if err := validateHighEdge(info.ID, "FDL_high", info.DLHighMHz, band.endFreqDl()); err != nil {
+		return nil, err
+	}
+	if mode != FDDMode {
+		if info.EarfcnUlMin != 0 || info.EarfcnUlMax != 0 {
+			return nil, fmt.Errorf("Band %d: uplink range is only valid for FDD bands", info.ID)
+		}
+		if info.ULLowMHz == 0 && info.ULHighMHz == 0 {
+			return band, nil
+		}
+		if mode != TDDMode {
+			return nil, fmt.Errorf("Band %d: uplink frequencies are not valid for %s bands", info.ID, mode)
+		}
+		if info.ULLowMHz != info.DLLowMHz {
+			return nil, fmt.Errorf("Band %d: FUL_low of a TDD band must equal FDL_low", info.ID)
+		}
+		if err := validateHighEdge(info.ID, "FUL_high", info.ULHighMHz, band.endFreqDl()); err != nil {
+			return nil, err
+		}
+		return band, nil
+	}
+	if info.EarfcnUlMin < 0 || info.EarfcnUlMax < info.EarfcnUlMin || info.EarfcnUlMax > maxEARFCN {
+		return nil, fmt.Errorf("Band %d: invalid EARFCNUL range %d-%d", info.ID, info.EarfcnUlMin, info.EarfcnUlMax)
+	}
+	if info.EarfcnUlMax-info.EarfcnUlMin != info.EarfcnDlMax-info.EarfcnDlMin {
+		return nil, fmt.Errorf(
+			"Band %d: EARFCNUL range %d-%d must be the same size as EARFCNDL range %d-%d",
+			info.ID, info.EarfcnUlMin, info.EarfcnUlMax, info.EarfcnDlMin, info.EarfcnDlMax)
+	}
+	if !isFinite(info.ULLowMHz) || info.ULLowMHz <= 0 {
+		return nil, fmt.Errorf("Band %d: FUL_low must be a finite positive frequency", info.ID)
+	}
+	band.StartEarfcnUl = info.EarfcnUlMin
+	band.StartFreqUl = info.ULLowMHz
+	if err := validateHighEdge(info.ID, "FUL_high", info.ULHighMHz, band.FreqRanges().ULHigh); err != nil {
+		return nil, err
+	}
+	return band, nil
+}
+
+// validateHighEdge checks an optional upper edge of a band's frequency range
+// against the one derived from its EARFCN range. A zero edge is not set.
+func validateHighEdge(bandID int32, name string, highMHz, expectedMHz float64) error {
+	if highMHz == 0 {
+		return nil
+	}
+	if !isFinite(highMHz) || math.Abs(highMHz-expectedMHz) > freqEpsilon {
+		return fmt.Errorf(
+			"Band %d: %s %.1f MHz does not match the %.1f MHz derived from the EARFCN range",
+			bandID, name, highMHz, expectedMHz)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandInfo(t *testing.T) {
+	band, err := utils.GetBandByID(1)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		&utils.BandInfo{
+			ID:          1,
+			Name:        "IMT 2100",
+			Duplex:      "FDD",
+			EarfcnDlMin: 0,
+			EarfcnDlMax: 599,
+			EarfcnUlMin: 18000,
+			EarfcnUlMax: 18599,
+			DLLowMHz:    2110,
+			DLHighMHz:   2170,
+			ULLowMHz:    1920,
+			ULHighMHz:   1980,
+			NBIoT:       true,
+			LTEM:        true,
+		},
+		band.ToBandInfo(),
+	)
+
+	band, err = utils.GetBandByID(32)
+	assert.NoError(t, err)
+	marshaled, err := json.Marshal(band.ToBandInfo())
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"id":32,"name":"L-Band","duplex":"SDL","earfcndl_min":9920,"earfcndl_max":10359,
+		  "dl_low_mhz":1452,"dl_high_mhz":1496,"nb_iot":false,"lte_m":false}`,
+		string(marshaled),
+	)
+}
+
+func TestBandInfoRoundTrip(t *testing.T) {
+	for _, band := range utils.ListBands() {
+		fromInfo, err := utils.BandFromInfo(band.ToBandInfo())
+		assert.NoError(t, err)
+		assert.Equal(t, band, *fromInfo)
+	}
+}
+
+func TestBandInfoLoadBandTable(t *testing.T) {
+	// A band table exported as BandInfo can be loaded back
+	var infos []*utils.BandInfo
+	for _, band := range utils.ListBands() {
+		infos = append(infos, band.ToBandInfo())
+	}
+	marshaled, err := json.Marshal(infos)
+	assert.NoError(t, err)
+
+	table := utils.NewBandTable()
+	assert.NoError(t, table.Load(bytes.NewReader(marshaled)))
+	for _, band := range utils.ListBands() {
+		loaded, err := table.Lookup(band.StartEarfcnDl)
+		assert.NoError(t, err)
+		assert.Equal(t, band, *loaded)
+	}
+}
+
+func TestBandFromInfoError(t *testing.T) {
+	_, err := utils.BandFromInfo(&utils.BandInfo{ID: 1, Duplex: "XDD", EarfcnDlMax: 599, DLLowMHz: 2110})
+	assert.EqualError(t, err, `Band 1: Invalid duplex mode: "XDD", must be one of TDD, FDD, SDL`)
+	_, err = utils.BandFromInfo(&utils.BandInfo{ID: 40, Duplex: "TDD", EarfcnDlMin: 39649, EarfcnDlMax: 38650, DLLowMHz: 2300})
+	assert.EqualError(t, err, "Band 40: invalid EARFCNDL range 39649-38650")
+
+	band1 := utils.BandInfo{
+		ID: 1, Duplex: "FDD",
+		EarfcnDlMin: 0, EarfcnDlMax: 599, EarfcnUlMin: 18000, EarfcnUlMax: 18599,
+		DLLowMHz: 2110, ULLowMHz: 1920,
+	}
+	band40 := utils.BandInfo{ID: 40, Duplex: "TDD", EarfcnDlMin: 38650, EarfcnDlMax: 39649, DLLowMHz: 2300}
+	band32 := utils.BandInfo{ID: 32, Duplex: "SDL", EarfcnDlMin: 9920, EarfcnDlMax: 10359, DLLowMHz: 1452}
+	testCases := []struct {
+		update   func(info *utils.BandInfo)
+		base     utils.BandInfo
+		expected string
+	}{
+		{func(info *utils.BandInfo) { info.ID = 0 }, band1, "Band 0: band number must be positive"},
+		{func(info *utils.BandInfo) { info.ID = -1 }, band1, "Band -1: band number must be positive"},
+		{func(info *utils.BandInfo) { info.DLLowMHz = math.NaN() }, band1, "Band 1: FDL_low must be a finite positive frequency"},
+		{func(info *utils.BandInfo) { info.DLLowMHz = math.Inf(1) }, band1, "Band 1: FDL_low must be a finite positive frequency"},
+		{func(info *utils.BandInfo) { info.ULLowMHz = math.NaN() }, band1, "Band 1: FUL_low must be a finite positive frequency"},
+		{func(info *utils.BandInfo) { info.ULLowMHz = math.Inf(-1) }, band1, "Band 1: FUL_low must be a finite positive frequency"},
+		{func(info *utils.BandInfo) { info.MaxTxPower = math.NaN() }, band1, "Band 1: transmit power cap must be between 0 and 60 dBm"},
+		{func(info *utils.BandInfo) { info.MaxTxPower = math.Inf(1) }, band1, "Band 1: transmit power cap must be between 0 and 60 dBm"},
+		{
+			func(info *utils.BandInfo) { info.DLHighMHz = 2180 },
+			band1,
+			"Band 1: FDL_high 2180.0 MHz does not match the 2170.0 MHz derived from the EARFCN range",
+		},
+		{
+			func(info *utils.BandInfo) { info.DLHighMHz = math.NaN() },
+			band1,
+			"Band 1: FDL_high NaN MHz does not match the 2170.0 MHz derived from the EARFCN range",
+		},
+		{
+			func(info *utils.BandInfo) { info.ULHighMHz = 1970 },
+			band1,
+			"Band 1: FUL_high 1970.0 MHz does not match the 1980.0 MHz derived from the EARFCN range",
+		},
+		{func(info *utils.BandInfo) { info.ULLowMHz = 2310 }, band40, "Band 40: FUL_low of a TDD band must equal FDL_low"},
+		{
+			func(info *utils.BandInfo) { info.ULLowMHz, info.ULHighMHz = 2300, 2390 },
+			band40,
+			"Band 40: FUL_high 2390.0 MHz does not match the 2400.0 MHz derived from the EARFCN range",
+		},
+		{func(info *utils.BandInfo) { info.ULLowMHz = 1452 }, band32, "Band 32: uplink frequencies are not valid for SDL bands"},
+	}
+	for _, testCase := range testCases {
+		info := testCase.base
+		testCase.update(&info)
+		_, err = utils.BandFromInfo(&info)
+		assert.EqualError(t, err, testCase.expected)
+	}
+
+	// The upper edges may be left out or match the EARFCN ranges
+	for _, info := range []utils.BandInfo{band1, band40, band32} {
+		_, err = utils.BandFromInfo(&info)
+		assert.NoError(t, err)
+	}
+	band40.ULLowMHz, band40.ULHighMHz, band40.DLHighMHz = 2300, 2400, 2400
+	_, err = utils.BandFromInfo(&band40)
+	assert.NoError(t, err)
+}
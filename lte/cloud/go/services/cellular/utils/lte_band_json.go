@@ -0,0 +1,59 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// bandJSON is the JSON representation of a LTEBand, e.g.
+//
+//	{"id":1,"name":"IMT 2100","duplex":"FDD","earfcndl_min":0,"earfcndl_max":599}
+//
+// The EARFCN-DL range is inclusive on both ends.
+type bandJSON struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	Duplex      string `json:"duplex"`
+	EarfcnDlMin int32  `json:"earfcndl_min"`
+	EarfcnDlMax int32  `json:"earfcndl_max"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (band LTEBand) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bandJSON{
+		ID:          band.ID,
+		Name:        band.Name,
+		Duplex:      band.Mode.String(),
+		EarfcnDlMin: band.StartEarfcnDl,
+		EarfcnDlMax: band.StartEarfcnDl + band.CountEarfcn - 1,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The band is looked up by ID in
+// the band table, and the duplex mode and EARFCN-DL range must match the
+// table's definition.
+func (band *LTEBand) UnmarshalJSON(data []byte) error {
+	var parsed bandJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	tableBand, err := GetBandByID(parsed.ID)
+	if err != nil {
+		return err
+	}
+	if parsed.Duplex != tableBand.Mode.String() ||
+		parsed.EarfcnDlMin != tableBand.StartEarfcnDl ||
+		parsed.EarfcnDlMax != tableBand.StartEarfcnDl+tableBand.CountEarfcn-1 {
+		return fmt.Errorf("Band %d: duplex mode or EARFCNDL range does not match the band table, expected %s", parsed.ID, tableBand)
+	}
+	*band = *tableBand
+	return nil
+}
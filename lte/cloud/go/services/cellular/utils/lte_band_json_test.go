@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandJSON(t *testing.T) {
+	band, err := utils.GetBandByID(38)
+	assert.NoError(t, err)
+	marshaled, err := json.Marshal(band)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":38,"name":"Band 38","duplex":"TDD","earfcndl_min":37750,"earfcndl_max":38249}`, string(marshaled))
+
+	for _, band := range utils.ListBands() {
+		marshaled, err := json.Marshal(band)
+		assert.NoError(t, err)
+		var unmarshaled utils.LTEBand
+		assert.NoError(t, json.Unmarshal(marshaled, &unmarshaled))
+		assert.Equal(t, band, unmarshaled)
+	}
+}
+
+func TestBandJSONError(t *testing.T) {
+	var band utils.LTEBand
+	err := json.Unmarshal([]byte(`{"id":5,"name":"Band 5","duplex":"FDD","earfcndl_min":2400,"earfcndl_max":2649}`), &band)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+	assert.True(t, errors.Is(err, utils.ErrUnknownBand))
+
+	err = json.Unmarshal([]byte(`{"id":1,"name":"IMT 2100","duplex":"FDD","earfcndl_min":0,"earfcndl_max":299}`), &band)
+	assert.EqualError(t, err, "Band 1: duplex mode or EARFCNDL range does not match the band table, expected Band 1 - IMT 2100 (FDD, EARFCNDL 0-599)")
+
+	err = json.Unmarshal([]byte(`{"id":"1"}`), &band)
+	assert.Error(t, err)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// bandIndex is an immutable snapshot of a band table. byID is sorted by band
+// number, byEarfcnDl is sorted by StartEarfcnDl and earfcnDlUpperBounds
+// holds the (exclusive) last EARFCN-DL of each band of byEarfcnDl, so that
+// BandTable.Lookup can binary search over the upper bounds.
+type bandIndex struct {
+	byID                []LTEBand
+	byEarfcnDl          []LTEBand
+	earfcnDlUpperBounds []int32
+}
+
+// BandTable is a set of band definitions that EARFCN-DL values can be looked
+// up in. GetBand and the other helpers of this package use a default
+// instance; networks with their own (e.g. regional) band tables can hold a
+// BandTable of their own instead of replacing the default one. The zero value
+// holds the built-in bands.
+//
+// Only Lookup uses the bands of the instance. GetBandByID,
+// ValidateChannelFits, BandForFrequencyMHz and the other package level
+// helpers always use the default band table.
+//
+// Lookups take a snapshot of the index under the read lock and never see a
+// partially replaced table, so all methods are safe to call concurrently
+// with Load and Reset.
+type BandTable struct {
+	mu    sync.RWMutex
+	index *bandIndex
+}
+
+// builtinBands is the index of the built-in band table
+var builtinBands = newBuiltinBandIndex()
+
+func newBuiltinBandIndex() *bandIndex {
+	index, err := newBandIndex(defaultBands[:])
+	if err != nil {
+		panic(err)
+	}
+	return index
+}
+
+// defaultBandTable is the band table used by the package level helpers
+var defaultBandTable = NewBandTable()
+
+// NewBandTable returns a band table holding the built-in bands
+func NewBandTable() *BandTable {
+	return &BandTable{}
+}
+
+// snapshot returns the band index in use, the built-in one unless bands have
+// been loaded
+func (table *BandTable) snapshot() *bandIndex {
+	table.mu.RLock()
+	defer table.mu.RUnlock()
+	if table.index == nil {
+		return builtinBands
+	}
+	return table.index
+}
+
+// currentBands returns the band index of the default band table
+func currentBands() *bandIndex {
+	return defaultBandTable.snapshot()
+}
+
+// Lookup returns the band of the table an EARFCN-DL belongs to, see GetBand
+func (table *BandTable) Lookup(earfcndl int32) (*LTEBand, error) {
+	return table.snapshot().lookup(earfcndl)
+}
+
+// lookup returns the band of the index an EARFCN-DL belongs to
+func (index *bandIndex) lookup(earfcndl int32) (*LTEBand, error) {
+	if earfcndl < 0 || earfcndl > maxEARFCN {
+		return nil, fmt.Errorf("Invalid EARFCNDL: %w", ErrNoMatchingBand)
+	}
+	// The ranges do not overlap, so the only candidate is the first band
+	// whose range ends after earfcndl. Upper bounds are exclusive, hence the
+	// strict comparison: earfcndl == upper bound belongs to the next band.
+	i := sort.Search(len(index.earfcnDlUpperBounds), func(i int) bool {
+		return earfcndl < index.earfcnDlUpperBounds[i]
+	})
+	if i < len(index.byEarfcnDl) && index.byEarfcnDl[i].EarfcnDLInRange(earfcndl) {
+		band := index.byEarfcnDl[i]
+		return &band, nil
+	}
+	return nil, fmt.Errorf("Invalid EARFCNDL: %w", ErrNoMatchingBand)
+}
+
+func parseDuplexMode(mode string) (DuplexMode, error) {
+	for _, candidate := range [...]DuplexMode{TDDMode, FDDMode, SDLMode} {
+		if candidate.String() == mode {
+			return candidate, nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid duplex mode: %q, must be one of TDD, FDD, SDL", mode)
+}
+
+// Load replaces the bands of the table with a JSON array of bands in the
+// BandInfo format, validated like BandFromInfo does, e.g.
+//
+//	[{"id": 1, "name": "IMT 2100", "duplex": "FDD", "earfcndl_min": 0, "earfcndl_max": 599,
+//	  "earfcnul_min": 18000, "earfcnul_max": 18599,
+//	  "dl_low_mhz": 2110, "ul_low_mhz": 1920}]
+//
+// The bands in use are left unchanged if the definitions are invalid.
+func (table *BandTable) Load(r io.Reader) error {
+	var infos []BandInfo
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&infos); err != nil {
+		return fmt.Errorf("Invalid band table: %s", err)
+	}
+
+	bands := make([]LTEBand, 0, len(infos))
+	for i := range infos {
+		band, err := BandFromInfo(&infos[i])
+		if err != nil {
+			return fmt.Errorf("Invalid band table: %s", err)
+		}
+		bands = append(bands, *band)
+	}
+	if err := table.set(bands); err != nil {
+		return fmt.Errorf("Invalid band table: %s", err)
+	}
+	return nil
+}
+
+// Reset restores the built-in bands
+func (table *BandTable) Reset() {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	table.index = nil
+}
+
+// set makes a list of bands the bands in use, see newBandIndex
+func (table *BandTable) set(bands []LTEBand) error {
+	index, err := newBandIndex(bands)
+	if err != nil {
+		return err
+	}
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	table.index = index
+	return nil
+}
+
+// LoadBandTable replaces the default band table, used by GetBand and the
+// other helpers of this package, see BandTable.Load
+func LoadBandTable(r io.Reader) error {
+	return defaultBandTable.Load(r)
+}
+
+// ResetBandTable restores the built-in bands of the default band table
+func ResetBandTable() {
+	defaultBandTable.Reset()
+}
+
+// newBandIndex checks that a table has unique band numbers and that no two
+// EARFCN-DL ranges, nor the EARFCN-UL ranges of two FDD bands, overlap before
+// indexing it. Bands without a name are named "Band <ID>".
+func newBandIndex(table []LTEBand) (*bandIndex, error) {
+	if len(table) == 0 {
+		return nil, fmt.Errorf("no bands defined")
+	}
+
+	byID := make([]LTEBand, len(table))
+	copy(byID, table)
+	for i := range byID {
+		if byID[i].Name == "" {
+			byID[i].Name = fmt.Sprintf("Band %d", byID[i].ID)
+		}
+	}
+	sort.Slice(byID, func(i, j int) bool { return byID[i].ID < byID[j].ID })
+	for i := 1; i < len(byID); i++ {
+		if byID[i].ID == byID[i-1].ID {
+			return nil, fmt.Errorf("Band %d is defined more than once", byID[i].ID)
+		}
+	}
+
+	byEarfcnDl := make([]LTEBand, len(byID))
+	copy(byEarfcnDl, byID)
+	sort.Slice(byEarfcnDl, func(i, j int) bool { return byEarfcnDl[i].StartEarfcnDl < byEarfcnDl[j].StartEarfcnDl })
+	upperBounds := make([]int32, len(byEarfcnDl))
+	for i, band := range byEarfcnDl {
+		if i > 0 && band.StartEarfcnDl < upperBounds[i-1] {
+			return nil, fmt.Errorf("EARFCNDL range of Band %d overlaps Band %d", band.ID, byEarfcnDl[i-1].ID)
+		}
+		upperBounds[i] = band.StartEarfcnDl + band.CountEarfcn
+	}
+
+	var fddBands []LTEBand
+	for _, band := range byID {
+		if band.Mode == FDDMode {
+			fddBands = append(fddBands, band)
+		}
+	}
+	sort.Slice(fddBands, func(i, j int) bool { return fddBands[i].StartEarfcnUl < fddBands[j].StartEarfcnUl })
+	for i := 1; i < len(fddBands); i++ {
+		prev := fddBands[i-1]
+		if fddBands[i].StartEarfcnUl < prev.StartEarfcnUl+prev.CountEarfcn {
+			return nil, fmt.Errorf("EARFCNUL range of Band %d overlaps Band %d", fddBands[i].ID, prev.ID)
+		}
+	}
+
+	return &bandIndex{byID: byID, byEarfcnDl: byEarfcnDl, earfcnDlUpperBounds: upperBounds}, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const customBandTable = `[
+	{"id": 1, "duplex": "FDD", "earfcndl_min": 0, "earfcndl_max": 299,
+	 "earfcnul_min": 18000, "earfcnul_max": 18299, "dl_low_mhz": 2110, "ul_low_mhz": 1920, "nb_iot": true},
+	{"id": 40, "name": "Custom 2300", "duplex": "TDD", "earfcndl_min": 38650, "earfcndl_max": 39649, "dl_low_mhz": 2300}
+]`
+
+func TestLoadBandTable(t *testing.T) {
+	defer utils.ResetBandTable()
+
+	err := utils.LoadBandTable(strings.NewReader(customBandTable))
+	assert.NoError(t, err)
+
+	band, err := utils.GetBand(299)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.LTEBand{ID: 1, Name: "Band 1", Mode: utils.FDDMode, StartEarfcnDl: 0, StartEarfcnUl: 18000, CountEarfcn: 300, StartFreqDl: 2110, StartFreqUl: 1920, NBIoT: true}, *band)
+	band, err = utils.GetBand(38650)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(40), band.ID)
+	assert.Equal(t, "Custom 2300", band.Name)
+	for _, earfcndl := range [...]int32{300, 600, 43590} {
+		_, err = utils.GetBand(earfcndl)
+		assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+	}
+	_, err = utils.GetBandByID(2)
+	assert.True(t, errors.Is(err, utils.ErrUnknownBand))
+	assert.Len(t, utils.ListBands(), 2)
+
+	utils.ResetBandTable()
+	band, err = utils.GetBand(600)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), band.ID)
+}
+
+func TestBandTable(t *testing.T) {
+	custom := utils.NewBandTable()
+	assert.NoError(t, custom.Load(strings.NewReader(customBandTable)))
+	builtin := utils.NewBandTable()
+
+	// Band 1 ends at EARFCNDL 299 in the custom table only
+	band, err := builtin.Lookup(450)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), band.ID)
+	_, err = custom.Lookup(450)
+	assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+
+	band, err = builtin.Lookup(38650)
+	assert.NoError(t, err)
+	assert.Equal(t, "TDD 2300", band.Name)
+	band, err = custom.Lookup(38650)
+	assert.NoError(t, err)
+	assert.Equal(t, "Custom 2300", band.Name)
+
+	// Loading a table of its own does not affect the default table
+	band, err = utils.GetBand(450)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), band.ID)
+
+	// Invalid definitions leave the table unchanged
+	assert.EqualError(t, custom.Load(strings.NewReader("[]")), "Invalid band table: no bands defined")
+	band, err = custom.Lookup(299)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(300), band.CountEarfcn)
+
+	custom.Reset()
+	band, err = custom.Lookup(450)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), band.ID)
+}
+
+func TestBandTableZeroValue(t *testing.T) {
+	var table utils.BandTable
+	band, err := table.Lookup(0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), band.ID)
+	_, err = table.Lookup(45590)
+	assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+
+	assert.NoError(t, table.Load(strings.NewReader(customBandTable)))
+	_, err = table.Lookup(450)
+	assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+	table.Reset()
+	band, err = table.Lookup(450)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), band.ID)
+}
+
+func TestLoadBandTableError(t *testing.T) {
+	defer utils.ResetBandTable()
+
+	tests := []struct {
+		table       string
+		errExpected string
+	}{
+		{
+			`{}`,
+			"Invalid band table: json: cannot unmarshal object into Go value of type []utils.BandInfo",
+		},
+		{
+			`[]`,
+			"Invalid band table: no bands defined",
+		},
+		{
+			`[{"id": 1, "duplex": "FDD", "earfcndl_min": 0, "earfcndl_max": 599, "fdl": 2110}]`,
+			`Invalid band table: json: unknown field "fdl"`,
+		},
+		{
+			`[{"id": 1, "duplex": "XDD", "earfcndl_min": 0, "earfcndl_max": 599, "dl_low_mhz": 2110}]`,
+			`Invalid band table: Band 1: Invalid duplex mode: "XDD", must be one of TDD, FDD, SDL`,
+		},
+		{
+			`[{"id": 40, "duplex": "TDD", "earfcndl_min": 39649, "earfcndl_max": 38650, "dl_low_mhz": 2300}]`,
+			"Invalid band table: Band 40: invalid EARFCNDL range 39649-38650",
+		},
+		{
+			`[{"id": 40, "duplex": "TDD", "earfcndl_min": 38650, "earfcndl_max": 39649}]`,
+			"Invalid band table: Band 40: FDL_low must be a finite positive frequency",
+		},
+		{
+			`[{"id": 48, "duplex": "TDD", "earfcndl_min": 55240, "earfcndl_max": 56739,
+			   "dl_low_mhz": 3550, "max_tx_power_dbm": 70}]`,
+			"Invalid band table: Band 48: transmit power cap must be between 0 and 60 dBm",
+		},
+		{
+			`[{"id": 40, "duplex": "TDD", "earfcndl_min": 38650, "earfcndl_max": 39649,
+			   "earfcnul_min": 1, "earfcnul_max": 2, "dl_low_mhz": 2300}]`,
+			"Invalid band table: Band 40: uplink range is only valid for FDD bands",
+		},
+		{
+			`[{"id": 1, "duplex": "TDD", "earfcndl_min": 0, "earfcndl_max": 2147483647, "dl_low_mhz": 2110}]`,
+			"Invalid band table: Band 1: invalid EARFCNDL range 0-2147483647",
+		},
+		{
+			`[{"id": 1, "duplex": "FDD", "earfcndl_min": 0, "earfcndl_max": 599,
+			   "earfcnul_min": 18000, "earfcnul_max": -2147483648, "dl_low_mhz": 2110}]`,
+			"Invalid band table: Band 1: invalid EARFCNUL range 18000--2147483648",
+		},
+		{
+			`[{"id": 1, "duplex": "FDD", "earfcndl_min": 0, "earfcndl_max": 599,
+			   "earfcnul_min": 18000, "earfcnul_max": 18000, "dl_low_mhz": 2110}]`,
+			"Invalid band table: Band 1: EARFCNUL range 18000-18000 must be the same size as EARFCNDL range 0-599",
+		},
+		{
+			`[{"id": 1, "duplex": "FDD", "earfcndl_min": 0, "earfcndl_max": 599,
+			   "earfcnul_min": 18000, "earfcnul_max": 18599, "dl_low_mhz": 2110}]`,
+			"Invalid band table: Band 1: FUL_low must be a finite positive frequency",
+		},
+		{
+			`[{"id": 40, "duplex": "TDD", "earfcndl_min": 38650, "earfcndl_max": 39649, "dl_low_mhz": 2300},
+			  {"id": 41, "duplex": "TDD", "earfcndl_min": 39649, "earfcndl_max": 41589, "dl_low_mhz": 2496}]`,
+			"Invalid band table: EARFCNDL range of Band 41 overlaps Band 40",
+		},
+		{
+			`[{"id": 1, "duplex": "FDD", "earfcndl_min": 0, "earfcndl_max": 599,
+			   "earfcnul_min": 18000, "earfcnul_max": 18599, "dl_low_mhz": 2110, "ul_low_mhz": 1920},
+			  {"id": 2, "duplex": "FDD", "earfcndl_min": 600, "earfcndl_max": 1199,
+			   "earfcnul_min": 18599, "earfcnul_max": 19198, "dl_low_mhz": 1930, "ul_low_mhz": 1850}]`,
+			"Invalid band table: EARFCNUL range of Band 2 overlaps Band 1",
+		},
+		{
+			`[{"id": 40, "duplex": "TDD", "earfcndl_min": 38650, "earfcndl_max": 39649, "dl_low_mhz": 2300},
+			  {"id": 40, "duplex": "TDD", "earfcndl_min": 39650, "earfcndl_max": 41589, "dl_low_mhz": 2496}]`,
+			"Invalid band table: Band 40 is defined more than once",
+		},
+	}
+
+	for _, test := range tests {
+		assert.EqualError(t, utils.LoadBandTable(strings.NewReader(test.table)), test.errExpected)
+	}
+
+	// The table in use is unchanged after a failed load
+	band, err := utils.GetBand(600)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), band.ID)
+}
+
+func TestBandTableConcurrentReload(t *testing.T) {
+	defer utils.ResetBandTable()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// Band 1 starts at EARFCNDL 0 in both tables
+				band, err := utils.GetBand(0)
+				assert.NoError(t, err)
+				assert.Equal(t, int32(1), band.ID)
+				assert.NotEmpty(t, utils.ListBands())
+				_, err = utils.GetBandByID(40)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, utils.LoadBandTable(strings.NewReader(customBandTable)))
+		utils.ResetBandTable()
+	}
+	close(done)
+	wg.Wait()
+}
@@ -8,15 +8,49 @@ LICENSE file in the root directory of this source tree.
 
 package utils
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // LTEBand struct for converting EARFCN to Band
 type LTEBand struct {
-	ID            int32
+	ID int32
+	// Name is the common name of the band, "Band <ID>" if it has none
+	Name          string
 	Mode          DuplexMode
 	CountEarfcn   int32
 	StartEarfcnDl int32
 	StartEarfcnUl int32
+	// StartFreqDl is the downlink frequency (FDL_low) of StartEarfcnDl in MHz
+	StartFreqDl float64
+	// StartFreqUl is the uplink frequency (FUL_low) of StartEarfcnUl in MHz,
+	// FDD bands only
+	StartFreqUl float64
+	// NBIoT and LTEM are set if the band is defined for UE categories NB1/NB2
+	// and M1/M2 respectively (3GPP TS 36.101 Tables 5.5F-1 and 5.5E-1)
+	NBIoT bool
+	LTEM  bool
+	// MaxTxPowerDBm is the regulatory cap on the transmit power (EIRP) in dBm,
+	// 0 if the band has no cap of its own
+	MaxTxPowerDBm float64
+}
+
+// lteRasterKHz is the LTE channel raster, the frequency step between
+// consecutive EARFCNs of a band (3GPP TS 36.101 Section 5.7.2)
+const lteRasterKHz = 100
+
+// maxEARFCN is the highest EARFCN (3GPP TS 36.101 Section 5.7.3, 18 bits)
+const maxEARFCN = 262143
+
+// FrequencyRange holds the downlink and uplink frequency ranges of a band in
+// MHz. TDD bands use the same range for both directions and SDL bands have no
+// uplink range (ULLow and ULHigh are 0).
+type FrequencyRange struct {
+	DLLow  float64
+	DLHigh float64
+	ULLow  float64
+	ULHigh float64
 }
 
 // DuplexMode of LTE Band
@@ -27,42 +61,367 @@ const (
 	TDDMode DuplexMode = iota
 	// FDDMode
 	FDDMode
+	// SDLMode (supplemental downlink, no uplink channel)
+	SDLMode
 )
 
-var bands = [...]LTEBand{
+func (mode DuplexMode) String() string {
+	switch mode {
+	case TDDMode:
+		return "TDD"
+	case FDDMode:
+		return "FDD"
+	case SDLMode:
+		return "SDL"
+	}
+	return fmt.Sprintf("DuplexMode(%d)", int32(mode))
+}
+
+// defaultBands is the built-in band table, used unless LoadBandTable
+// replaces it
+var defaultBands = [...]LTEBand{
 	// FDDMode
-	{ID: 1, Mode: FDDMode, StartEarfcnDl: 0, StartEarfcnUl: 18000, CountEarfcn: 600},
-	{ID: 2, Mode: FDDMode, StartEarfcnDl: 600, StartEarfcnUl: 18600, CountEarfcn: 600},
-	{ID: 3, Mode: FDDMode, StartEarfcnDl: 1200, StartEarfcnUl: 19200, CountEarfcn: 750},
-	{ID: 4, Mode: FDDMode, StartEarfcnDl: 1950, StartEarfcnUl: 19950, CountEarfcn: 450},
-	{ID: 28, Mode: FDDMode, StartEarfcnDl: 9210, StartEarfcnUl: 27210, CountEarfcn: 450},
+	{ID: 1, Name: "IMT 2100", Mode: FDDMode, StartEarfcnDl: 0, StartEarfcnUl: 18000, CountEarfcn: 600, StartFreqDl: 2110, StartFreqUl: 1920, NBIoT: true, LTEM: true},
+	{ID: 2, Name: "PCS 1900", Mode: FDDMode, StartEarfcnDl: 600, StartEarfcnUl: 18600, CountEarfcn: 600, StartFreqDl: 1930, StartFreqUl: 1850, NBIoT: true, LTEM: true},
+	{ID: 3, Name: "DCS 1800", Mode: FDDMode, StartEarfcnDl: 1200, StartEarfcnUl: 19200, CountEarfcn: 750, StartFreqDl: 1805, StartFreqUl: 1710, NBIoT: true, LTEM: true},
+	{ID: 4, Name: "AWS-1", Mode: FDDMode, StartEarfcnDl: 1950, StartEarfcnUl: 19950, CountEarfcn: 450, StartFreqDl: 2110, StartFreqUl: 1710, NBIoT: true, LTEM: true},
+	{ID: 7, Name: "IMT-E 2600", Mode: FDDMode, StartEarfcnDl: 2750, StartEarfcnUl: 20750, CountEarfcn: 700, StartFreqDl: 2620, StartFreqUl: 2500, LTEM: true},
+	{ID: 20, Name: "800 DD", Mode: FDDMode, StartEarfcnDl: 6150, StartEarfcnUl: 24150, CountEarfcn: 300, StartFreqDl: 791, StartFreqUl: 832, NBIoT: true, LTEM: true},
+	{ID: 28, Name: "APT 700", Mode: FDDMode, StartEarfcnDl: 9210, StartEarfcnUl: 27210, CountEarfcn: 450, StartFreqDl: 758, StartFreqUl: 703, NBIoT: true, LTEM: true},
+	// SDLMode
+	{ID: 32, Name: "L-Band", Mode: SDLMode, StartEarfcnDl: 9920, CountEarfcn: 440, StartFreqDl: 1452},
 	// TDDMode
-	{ID: 40, Mode: TDDMode, StartEarfcnDl: 38650, CountEarfcn: 1000},
-	{ID: 41, Mode: TDDMode, StartEarfcnDl: 39650, CountEarfcn: 1940},
-	{ID: 42, Mode: TDDMode, StartEarfcnDl: 41590, CountEarfcn: 2000},
-	{ID: 43, Mode: TDDMode, StartEarfcnDl: 43590, CountEarfcn: 2000},
-	{ID: 48, Mode: TDDMode, StartEarfcnDl: 55240, CountEarfcn: 1500},
+	{ID: 38, Mode: TDDMode, StartEarfcnDl: 37750, CountEarfcn: 500, StartFreqDl: 2570},
+	{ID: 39, Mode: TDDMode, StartEarfcnDl: 38250, CountEarfcn: 400, StartFreqDl: 1880, LTEM: true},
+	{ID: 40, Name: "TDD 2300", Mode: TDDMode, StartEarfcnDl: 38650, CountEarfcn: 1000, StartFreqDl: 2300, LTEM: true},
+	{ID: 41, Name: "TDD 2500", Mode: TDDMode, StartEarfcnDl: 39650, CountEarfcn: 1940, StartFreqDl: 2496, NBIoT: true, LTEM: true},
+	{ID: 42, Name: "TDD 3500", Mode: TDDMode, StartEarfcnDl: 41590, CountEarfcn: 2000, StartFreqDl: 3400, NBIoT: true},
+	{ID: 43, Name: "TDD 3700", Mode: TDDMode, StartEarfcnDl: 43590, CountEarfcn: 2000, StartFreqDl: 3600, NBIoT: true},
+	{ID: 48, Name: "CBRS", Mode: TDDMode, StartEarfcnDl: 55240, CountEarfcn: 1500, StartFreqDl: 3550, MaxTxPowerDBm: 47},
+	// FDDMode, EARFCNs above 65535
+	{ID: 65, Name: "Extended IMT 2100", Mode: FDDMode, StartEarfcnDl: 65536, StartEarfcnUl: 131072, CountEarfcn: 900, StartFreqDl: 2110, StartFreqUl: 1920, NBIoT: true, LTEM: true},
+	{ID: 71, Name: "600 MHz", Mode: FDDMode, StartEarfcnDl: 68586, StartEarfcnUl: 133122, CountEarfcn: 350, StartFreqDl: 617, StartFreqUl: 663, NBIoT: true, LTEM: true},
+}
+
+func (band LTEBand) String() string {
+	name := fmt.Sprintf("Band %d", band.ID)
+	if band.Name != "" && band.Name != name {
+		name = fmt.Sprintf("%s - %s", name, band.Name)
+	}
+	return fmt.Sprintf(
+		"%s (%s, EARFCNDL %d-%d)",
+		name, band.Mode, band.StartEarfcnDl, band.StartEarfcnDl+band.CountEarfcn-1)
 }
 
-// EarfcnDLInRange checks that an EARFCN-DL belongs to a band
+// EarfcnDLInRange checks that an EARFCN-DL belongs to a band. Bands cover
+// the half-open range [StartEarfcnDl, StartEarfcnDl+CountEarfcn): the first
+// EARFCN-DL belongs to the band, the one after the last does not.
 func (band LTEBand) EarfcnDLInRange(earfcndl int32) bool {
-	return band.StartEarfcnDl <= earfcndl && earfcndl < band.StartEarfcnDl+band.CountEarfcn
+	return earfcnInRange(earfcndl, band.StartEarfcnDl, band.CountEarfcn)
 }
 
 // EarfcnULInRange checks that an EARFCN-UL belongs to a band
 func (band LTEBand) EarfcnULInRange(earfcnul int32) bool {
-	if band.Mode == FDDMode {
-		return band.StartEarfcnUl <= earfcnul && earfcnul < band.StartEarfcnUl+band.CountEarfcn
+	switch band.Mode {
+	case FDDMode:
+		return earfcnInRange(earfcnul, band.StartEarfcnUl, band.CountEarfcn)
+	case SDLMode:
+		return false
 	}
 	return band.EarfcnDLInRange(earfcnul)
 }
 
-// GetBand for a EARFCN-UL
+// earfcnInRange checks that start <= earfcn < start+count without computing
+// start+count, which could overflow for arbitrary inputs
+func earfcnInRange(earfcn, start, count int32) bool {
+	return start <= earfcn && int64(earfcn)-int64(start) < int64(count)
+}
+
+// endFreqDl returns the upper edge (FDL_high) of the band's downlink range in MHz
+func (band LTEBand) endFreqDl() float64 {
+	return band.StartFreqDl + float64(band.CountEarfcn)/10
+}
+
+// FreqRanges returns the downlink and uplink frequency ranges of a band
+func (band LTEBand) FreqRanges() FrequencyRange {
+	ret := FrequencyRange{DLLow: band.StartFreqDl, DLHigh: band.endFreqDl()}
+	switch band.Mode {
+	case FDDMode:
+		ret.ULLow = band.StartFreqUl
+		ret.ULHigh = band.StartFreqUl + float64(band.CountEarfcn)/10
+	case TDDMode:
+		ret.ULLow, ret.ULHigh = ret.DLLow, ret.DLHigh
+	}
+	return ret
+}
+
+// GetBand for a EARFCN-DL, looked up in the default band table. Band ranges
+// are half-open (see EarfcnDLInRange), so adjacent bands such as 1 (0-599)
+// and 2 (600-1199) never both match.
 func GetBand(earfcndl int32) (*LTEBand, error) {
-	for _, band := range bands {
-		if band.EarfcnDLInRange(earfcndl) {
+	return defaultBandTable.Lookup(earfcndl)
+}
+
+// BandForFrequencyMHz returns the band whose downlink range contains a
+// frequency. Like EARFCN-DL ranges, frequency ranges are half-open:
+// FDL_low belongs to the band and FDL_high does not. Bands with overlapping
+// downlink ranges (e.g. 1 and 4) resolve to the lowest band number.
+func BandForFrequencyMHz(freqMHz float64) (*LTEBand, error) {
+	for _, band := range currentBands().byID {
+		if band.freqDlInRange(freqMHz) {
+			return &band, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid frequency %.1f MHz: %w", freqMHz, ErrNoMatchingBand)
+}
+
+// freqDlInRange checks that a frequency is in the half-open downlink range
+// [FDL_low, FDL_high) of a band
+func (band LTEBand) freqDlInRange(freqMHz float64) bool {
+	return band.StartFreqDl-freqEpsilon <= freqMHz && freqMHz < band.endFreqDl()-freqEpsilon
+}
+
+// GetCandidateBands returns every band whose downlink frequency range
+// contains the downlink frequency of an EARFCN-DL, sorted by band number.
+// EARFCN-DL ranges of different bands never overlap, but their frequency
+// ranges may (e.g. band 4 is a subset of band 65), so the result holds the
+// band returned by GetBand and possibly others.
+func GetCandidateBands(earfcndl int32) ([]LTEBand, error) {
+	index := currentBands()
+	band, err := index.lookup(earfcndl)
+	if err != nil {
+		return nil, err
+	}
+	freqMHz := band.freqDl(earfcndl)
+	var ret []LTEBand
+	for _, band := range index.byID {
+		if band.freqDlInRange(freqMHz) {
+			ret = append(ret, band)
+		}
+	}
+	return ret, nil
+}
+
+// GetBands looks up the band of each EARFCN-DL. For every input, either the
+// band or the error at the same index is set.
+func GetBands(earfcndls []int32) ([]*LTEBand, []error) {
+	ret := make([]*LTEBand, len(earfcndls))
+	errs := make([]error, len(earfcndls))
+	for i, earfcndl := range earfcndls {
+		ret[i], errs[i] = GetBand(earfcndl)
+	}
+	return ret, errs
+}
+
+// ValidateNeighborEARFCNs checks that every EARFCN-DL of a neighbor frequency
+// list belongs to a band and returns an error naming the index of each
+// invalid entry
+func ValidateNeighborEARFCNs(earfcns []int32) []error {
+	var errs []error
+	for i, earfcndl := range earfcns {
+		if _, err := GetBand(earfcndl); err != nil {
+			errs = append(errs, fmt.Errorf("Neighbor %d (EARFCNDL=%d): %w", i, earfcndl, err))
+		}
+	}
+	return errs
+}
+
+// GetBandByID returns the band definition for a band number
+func GetBandByID(id int32) (*LTEBand, error) {
+	for _, band := range currentBands().byID {
+		if band.ID == id {
 			return &band, nil
 		}
 	}
-	return nil, fmt.Errorf("Invalid EARFCNDL: no matching band")
+	return nil, fmt.Errorf("Invalid band: %w", ErrUnknownBand)
+}
+
+// GetBandRange returns the first and last EARFCN-DL of a band (both inclusive)
+func GetBandRange(id int32) (int32, int32, error) {
+	band, err := GetBandByID(id)
+	if err != nil {
+		return 0, 0, err
+	}
+	return band.StartEarfcnDl, band.StartEarfcnDl + band.CountEarfcn - 1, nil
+}
+
+// BandChannelEndpoints returns the first and last EARFCN-DL of a band, see
+// GetBandRange
+func BandChannelEndpoints(bandID int32) (min, max int32, err error) {
+	return GetBandRange(bandID)
+}
+
+// BandChannelCount returns the number of EARFCN-DL values of a band
+func BandChannelCount(bandID int32) (int32, error) {
+	min, max, err := BandChannelEndpoints(bandID)
+	if err != nil {
+		return 0, err
+	}
+	return max - min + 1, nil
+}
+
+// ListBands returns a copy of all supported bands, sorted by band number
+func ListBands() []LTEBand {
+	index := currentBands()
+	ret := make([]LTEBand, len(index.byID))
+	copy(ret, index.byID)
+	return ret
+}
+
+// GetDuplexMode returns the duplex mode of the band an EARFCN-DL belongs to
+func GetDuplexMode(earfcndl int32) (DuplexMode, error) {
+	band, err := GetBand(earfcndl)
+	if err != nil {
+		return 0, err
+	}
+	return band.Mode, nil
+}
+
+// GetUplinkEARFCN returns the EARFCN-UL paired with an EARFCN-DL. The uplink
+// channel keeps the same offset into the band as the downlink channel, i.e.
+// EARFCNUL = EARFCNDL - StartEarfcnDl + StartEarfcnUl. TDD bands share a
+// single EARFCN for both directions, so an error is returned for them.
+func GetUplinkEARFCN(earfcndl int32) (int32, error) {
+	band, err := GetBand(earfcndl)
+	if err != nil {
+		return 0, err
+	}
+	if band.Mode != FDDMode {
+		return 0, fmt.Errorf("Not a FDD Band: %d", band.ID)
+	}
+	return earfcndl - band.StartEarfcnDl + band.StartEarfcnUl, nil
+}
+
+// FrequencyForEARFCNDL returns the downlink center frequency in MHz of an
+// EARFCN-DL, using the 100 kHz channel raster:
+// FDL = FDL_low + 0.1 * (EARFCNDL - N_Offs-DL)
+func FrequencyForEARFCNDL(earfcndl int32) (float64, error) {
+	band, err := GetBand(earfcndl)
+	if err != nil {
+		return 0, err
+	}
+	return band.freqDl(earfcndl), nil
+}
+
+// freqDl returns the downlink center frequency in MHz of an EARFCN-DL of the
+// band, see FrequencyForEARFCNDL
+func (band LTEBand) freqDl(earfcndl int32) float64 {
+	return band.StartFreqDl + float64(earfcndl-band.StartEarfcnDl)/10
+}
+
+// EARFCNDLForFrequencyMHz returns the EARFCN-DL of a downlink center
+// frequency, the inverse of FrequencyForEARFCNDL. The band is chosen like
+// BandForFrequencyMHz does. Frequencies that are not on the channel raster of
+// the band are rejected rather than rounded to the nearest EARFCN-DL.
+func EARFCNDLForFrequencyMHz(freqMHz float64) (int32, error) {
+	band, err := BandForFrequencyMHz(freqMHz)
+	if err != nil {
+		return 0, err
+	}
+	steps := (freqMHz - band.StartFreqDl) * 1000 / lteRasterKHz
+	rounded := math.Round(steps)
+	if math.Abs(steps-rounded)*lteRasterKHz/1000 > freqEpsilon {
+		return 0, fmt.Errorf("Invalid frequency %.3f MHz: not on the %d kHz channel raster of Band %d", freqMHz, lteRasterKHz, band.ID)
+	}
+	return band.StartEarfcnDl + int32(rounded), nil
+}
+
+// BandRasterKHz returns the channel raster of a band in kHz. All bands use
+// the 100 kHz LTE channel raster.
+func BandRasterKHz(bandID int32) (int32, error) {
+	if _, err := GetBandByID(bandID); err != nil {
+		return 0, err
+	}
+	return lteRasterKHz, nil
+}
+
+// IsEARFCNOnRaster checks that an EARFCN-DL corresponds to a frequency on the
+// channel raster of its band. Every EARFCN-DL of a band is one raster step
+// apart, so this only fails for EARFCN-DLs that belong to no band; use
+// EARFCNDLForFrequencyMHz to check frequencies.
+func IsEARFCNOnRaster(earfcndl int32) (bool, error) {
+	_, err := GetBand(earfcndl)
+	return err == nil, err
+}
+
+// ValidateEARFCNUL checks that an EARFCN-UL is a valid uplink channel for the
+// FDD band an EARFCN-DL belongs to
+func ValidateEARFCNUL(earfcndl, earfcnul int32) error {
+	band, err := GetBand(earfcndl)
+	if err != nil {
+		return err
+	}
+	if band.Mode != FDDMode {
+		return fmt.Errorf("Not a FDD Band: %d", band.ID)
+	}
+	if band.EarfcnULInRange(earfcnul) {
+		return nil
+	}
+	for _, other := range currentBands().byID {
+		if other.Mode == FDDMode && other.EarfcnULInRange(earfcnul) {
+			return fmt.Errorf(
+				"EARFCNUL=%d belongs to Band %d, but EARFCNDL=%d belongs to Band %d",
+				earfcnul, other.ID, earfcndl, band.ID)
+		}
+	}
+	return fmt.Errorf("EARFCNUL=%d invalid for Band %d (%d, %d)",
+		earfcnul,
+		band.ID,
+		band.StartEarfcnUl,
+		band.StartEarfcnUl+band.CountEarfcn)
+}
+
+// DuplexSpacingMHz returns the spacing between the downlink and uplink
+// ranges (FDL_low - FUL_low) of a FDD band. The spacing is negative for
+// reverse duplex bands such as 71, where the uplink is above the downlink.
+func DuplexSpacingMHz(id int32) (float64, error) {
+	band, err := GetBandByID(id)
+	if err != nil {
+		return 0, err
+	}
+	if band.Mode != FDDMode {
+		return 0, fmt.Errorf("Not a FDD Band: %d", band.ID)
+	}
+	return band.StartFreqDl - band.StartFreqUl, nil
+}
+
+// SupportsNBIoT checks whether NB-IoT is defined for a band
+func SupportsNBIoT(bandID int32) (bool, error) {
+	band, err := GetBandByID(bandID)
+	if err != nil {
+		return false, err
+	}
+	return band.NBIoT, nil
+}
+
+// SupportsLTEM checks whether LTE-M is defined for a band
+func SupportsLTEM(bandID int32) (bool, error) {
+	band, err := GetBandByID(bandID)
+	if err != nil {
+		return false, err
+	}
+	return band.LTEM, nil
+}
+
+// minTxPowerDBm and maxTxPowerDBm bound the transmit power of all bands,
+// including bands without a cap of their own
+const (
+	minTxPowerDBm = -30
+	maxTxPowerDBm = 60
+)
+
+// ValidateTxPower checks that a transmit power is between minTxPowerDBm and
+// maxTxPowerDBm and does not exceed the cap of a band
+func ValidateTxPower(bandID int32, txPowerDBm float64) error {
+	band, err := GetBandByID(bandID)
+	if err != nil {
+		return err
+	}
+	if math.IsNaN(txPowerDBm) || txPowerDBm < minTxPowerDBm || txPowerDBm > maxTxPowerDBm {
+		return fmt.Errorf("Invalid transmit power: %.1f dBm, must be between %d and %d dBm", txPowerDBm, minTxPowerDBm, maxTxPowerDBm)
+	}
+	if band.MaxTxPowerDBm != 0 && txPowerDBm > band.MaxTxPowerDBm {
+		return fmt.Errorf("Invalid transmit power: %.1f dBm exceeds the %.1f dBm cap of Band %d", txPowerDBm, band.MaxTxPowerDBm, band.ID)
+	}
+	return nil
 }
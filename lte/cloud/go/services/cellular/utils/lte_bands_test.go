@@ -9,9 +9,14 @@ LICENSE file in the root directory of this source tree.
 package utils_test
 
 import (
-	"github.com/stretchr/testify/assert"
-	"magma/lte/cloud/go/services/cellular/utils"
+	"errors"
+	"fmt"
+	"math"
 	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetBand(t *testing.T) {
@@ -20,9 +25,19 @@ func TestGetBand(t *testing.T) {
 		599:   1,
 		600:   2,
 		749:   2,
+		6150:  20,
+		6449:  20,
+		9920:  32,
+		37750: 38,
+		38250: 39,
 		38650: 40,
 		43590: 43,
 		45589: 43,
+		65536: 65,
+		66000: 65,
+		66435: 65,
+		68586: 71,
+		68935: 71,
 	}
 
 	for earfcndl, bandExpected := range expected {
@@ -32,11 +47,595 @@ func TestGetBand(t *testing.T) {
 	}
 }
 
+// TestGetBandBoundaries pins the half-open [low, high) EARFCN-DL ranges:
+// the first and last EARFCN-DL of every band resolve to it, and their outer
+// neighbors resolve to another band or to none.
+func TestGetBandBoundaries(t *testing.T) {
+	for _, band := range utils.ListBands() {
+		first := band.StartEarfcnDl
+		last := band.StartEarfcnDl + band.CountEarfcn - 1
+		for _, earfcndl := range [...]int32{first, first + 1, last - 1, last} {
+			actual, err := utils.GetBand(earfcndl)
+			assert.NoError(t, err, "EARFCNDL %d", earfcndl)
+			assert.Equal(t, band.ID, actual.ID, "EARFCNDL %d", earfcndl)
+			assert.True(t, band.EarfcnDLInRange(earfcndl), "EARFCNDL %d", earfcndl)
+		}
+		for _, earfcndl := range [...]int32{first - 1, last + 1} {
+			actual, err := utils.GetBand(earfcndl)
+			if err == nil {
+				assert.NotEqual(t, band.ID, actual.ID, "EARFCNDL %d", earfcndl)
+			}
+			assert.False(t, band.EarfcnDLInRange(earfcndl), "EARFCNDL %d", earfcndl)
+		}
+	}
+
+	// Adjacent bands: the exclusive upper bound of one band is the first
+	// EARFCN-DL of the next
+	expected := map[int32]int32{
+		599:  1,
+		600:  2,
+		1199: 2,
+		1200: 3,
+		1949: 3,
+		1950: 4,
+	}
+	for earfcndl, bandExpected := range expected {
+		band, err := utils.GetBand(earfcndl)
+		assert.NoError(t, err)
+		assert.Equal(t, bandExpected, band.ID, "EARFCNDL %d", earfcndl)
+	}
+}
+
 func TestGetBandError(t *testing.T) {
-	expectedErr := [...]int32{-1, 45590, 45591}
+	expectedErr := [...]int32{math.MinInt32, -1, 45590, 45591, 65535, 66436, 68936, 262144, math.MaxInt32}
 
 	for _, earfcndl := range expectedErr {
 		_, err := utils.GetBand(earfcndl)
-		assert.Error(t, err, "Invalid EARFCNDL: no matching band")
+		assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+		assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+	}
+}
+
+// FuzzGetBand checks that GetBand either returns a band containing the
+// EARFCN-DL or ErrNoMatchingBand, for any input
+func FuzzGetBand(f *testing.F) {
+	for _, earfcndl := range [...]int32{math.MinInt32, -1, 0, 599, 600, 45590, 65535, 65536, 68935, 262143, math.MaxInt32} {
+		f.Add(earfcndl)
+	}
+	f.Fuzz(func(t *testing.T, earfcndl int32) {
+		band, err := utils.GetBand(earfcndl)
+		if err != nil {
+			if !errors.Is(err, utils.ErrNoMatchingBand) {
+				t.Fatalf("EARFCNDL %d: unexpected error %v", earfcndl, err)
+			}
+			return
+		}
+		if !band.EarfcnDLInRange(earfcndl) {
+			t.Fatalf("EARFCNDL %d: not in range of %s", earfcndl, band)
+		}
+	})
+}
+
+func TestEarfcnInRangeOverflow(t *testing.T) {
+	band := utils.LTEBand{ID: 1, Mode: utils.FDDMode, StartEarfcnDl: math.MaxInt32 - 10, StartEarfcnUl: math.MaxInt32 - 10, CountEarfcn: 100}
+	assert.True(t, band.EarfcnDLInRange(math.MaxInt32))
+	assert.True(t, band.EarfcnULInRange(math.MaxInt32))
+	assert.False(t, band.EarfcnDLInRange(math.MinInt32))
+	assert.False(t, band.EarfcnULInRange(math.MinInt32))
+}
+
+func TestGetBandMatchesLinearScan(t *testing.T) {
+	bands := utils.ListBands()
+	for earfcndl := int32(-1); earfcndl <= 70000; earfcndl++ {
+		var bandExpected *utils.LTEBand
+		for _, band := range bands {
+			if band.EarfcnDLInRange(earfcndl) {
+				bandExpected = &band
+				break
+			}
+		}
+
+		band, err := utils.GetBand(earfcndl)
+		if bandExpected == nil {
+			assert.Error(t, err, "EARFCNDL %d", earfcndl)
+			continue
+		}
+		assert.NoError(t, err, "EARFCNDL %d", earfcndl)
+		assert.Equal(t, *bandExpected, *band, "EARFCNDL %d", earfcndl)
+	}
+}
+
+func BenchmarkGetBand(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		utils.GetBand(55240)
+	}
+}
+
+func TestBandForFrequencyMHz(t *testing.T) {
+	expected := map[float64]int32{
+		2110.0:  1,
+		2169.9:  1,
+		2154.9:  1,
+		1930.0:  2,
+		1989.95: 2,
+		758.0:   28,
+		2300.0:  40,
+		2399.9:  40,
+		2496.0:  41,
+		3799.99: 43,
+	}
+
+	for freq, bandExpected := range expected {
+		band, err := utils.BandForFrequencyMHz(freq)
+		assert.NoError(t, err, "%f MHz", freq)
+		assert.Equal(t, bandExpected, band.ID, "%f MHz", freq)
+	}
+
+	// The frequency of every EARFCNDL belongs to a band
+	for _, earfcndl := range [...]int32{0, 599, 600, 1199, 9210, 9659, 38650, 39649, 45589} {
+		freq, err := utils.FrequencyForEARFCNDL(earfcndl)
+		assert.NoError(t, err)
+		_, err = utils.BandForFrequencyMHz(freq)
+		assert.NoError(t, err, "EARFCNDL %d", earfcndl)
+	}
+}
+
+func TestBandForFrequencyMHzError(t *testing.T) {
+	for _, freq := range [...]float64{0, -1, 2200.0, 757.9, 2400.0, 3800.0} {
+		_, err := utils.BandForFrequencyMHz(freq)
+		assert.True(t, errors.Is(err, utils.ErrNoMatchingBand), "%f MHz", freq)
+	}
+	_, err := utils.BandForFrequencyMHz(2200)
+	assert.EqualError(t, err, "Invalid frequency 2200.0 MHz: no matching band")
+}
+
+func TestGetCandidateBands(t *testing.T) {
+	expected := map[int32][]int32{
+		0:     {1, 4, 65},
+		600:   {2},
+		2000:  {1, 4, 65},
+		2750:  {7, 41},
+		37750: {38, 41},
+		38650: {40},
+		55240: {42, 48},
+		66000: {1, 65},
+	}
+
+	for earfcndl, bandsExpected := range expected {
+		bands, err := utils.GetCandidateBands(earfcndl)
+		assert.NoError(t, err)
+		var ids []int32
+		for _, band := range bands {
+			ids = append(ids, band.ID)
+		}
+		assert.Equal(t, bandsExpected, ids, "EARFCNDL %d", earfcndl)
+	}
+
+	_, err := utils.GetCandidateBands(45590)
+	assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+}
+
+func TestGetBands(t *testing.T) {
+	bands, errs := utils.GetBands([]int32{0, -1, 600, 45590, 38650})
+	assert.Len(t, bands, 5)
+	assert.Len(t, errs, 5)
+
+	for i, bandExpected := range [...]int32{1, 0, 2, 0, 40} {
+		if bandExpected == 0 {
+			assert.Nil(t, bands[i])
+			assert.True(t, errors.Is(errs[i], utils.ErrNoMatchingBand))
+			continue
+		}
+		assert.NoError(t, errs[i])
+		assert.Equal(t, bandExpected, bands[i].ID)
+	}
+
+	bands, errs = utils.GetBands(nil)
+	assert.Empty(t, bands)
+	assert.Empty(t, errs)
+}
+
+func BenchmarkGetBands(b *testing.B) {
+	earfcndls := make([]int32, 10000)
+	for i := range earfcndls {
+		earfcndls[i] = int32(i * 7 % 60000)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		utils.GetBands(earfcndls)
+	}
+}
+
+func TestBandString(t *testing.T) {
+	expected := map[int32]string{
+		1:  "Band 1 - IMT 2100 (FDD, EARFCNDL 0-599)",
+		32: "Band 32 - L-Band (SDL, EARFCNDL 9920-10359)",
+		38: "Band 38 (TDD, EARFCNDL 37750-38249)",
+		40: "Band 40 - TDD 2300 (TDD, EARFCNDL 38650-39649)",
+	}
+
+	for id, strExpected := range expected {
+		band, err := utils.GetBandByID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, strExpected, band.String())
+		assert.Equal(t, strExpected, fmt.Sprintf("%v", band))
+	}
+	assert.Equal(t, "DuplexMode(7)", utils.DuplexMode(7).String())
+	assert.Equal(t, "Band 7 (FDD, EARFCNDL 2750-3449)", utils.LTEBand{ID: 7, Mode: utils.FDDMode, StartEarfcnDl: 2750, CountEarfcn: 700}.String())
+}
+
+func TestBandName(t *testing.T) {
+	expected := map[int32]string{
+		1:  "IMT 2100",
+		40: "TDD 2300",
+		48: "CBRS",
+		// Unlabeled bands
+		38: "Band 38",
+		39: "Band 39",
+	}
+
+	for id, nameExpected := range expected {
+		band, err := utils.GetBandByID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, nameExpected, band.Name)
+	}
+
+	band, err := utils.GetBand(37750)
+	assert.NoError(t, err)
+	assert.Equal(t, "Band 38", band.Name)
+	band, err = utils.GetBand(55240)
+	assert.NoError(t, err)
+	assert.Equal(t, "CBRS", band.Name)
+}
+
+func TestBandFreqRanges(t *testing.T) {
+	expected := map[int32]utils.FrequencyRange{
+		1:  {DLLow: 2110, DLHigh: 2170, ULLow: 1920, ULHigh: 1980},
+		2:  {DLLow: 1930, DLHigh: 1990, ULLow: 1850, ULHigh: 1910},
+		20: {DLLow: 791, DLHigh: 821, ULLow: 832, ULHigh: 862},
+		32: {DLLow: 1452, DLHigh: 1496},
+		40: {DLLow: 2300, DLHigh: 2400, ULLow: 2300, ULHigh: 2400},
+		41: {DLLow: 2496, DLHigh: 2690, ULLow: 2496, ULHigh: 2690},
+	}
+
+	for id, rangesExpected := range expected {
+		band, err := utils.GetBandByID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, rangesExpected, band.FreqRanges())
+	}
+}
+
+func TestValidateNeighborEARFCNs(t *testing.T) {
+	assert.Empty(t, utils.ValidateNeighborEARFCNs(nil))
+	assert.Empty(t, utils.ValidateNeighborEARFCNs([]int32{}))
+	assert.Empty(t, utils.ValidateNeighborEARFCNs([]int32{0, 600, 38650}))
+
+	errs := utils.ValidateNeighborEARFCNs([]int32{0, -1, 600, 45590, 38650})
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "Neighbor 1 (EARFCNDL=-1): Invalid EARFCNDL: no matching band")
+	assert.EqualError(t, errs[1], "Neighbor 3 (EARFCNDL=45590): Invalid EARFCNDL: no matching band")
+	for _, err := range errs {
+		assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+	}
+}
+
+func TestGetBandByID(t *testing.T) {
+	band, err := utils.GetBandByID(1)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.LTEBand{ID: 1, Name: "IMT 2100", Mode: utils.FDDMode, StartEarfcnDl: 0, StartEarfcnUl: 18000, CountEarfcn: 600, StartFreqDl: 2110, StartFreqUl: 1920, NBIoT: true, LTEM: true}, *band)
+
+	band, err = utils.GetBandByID(40)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.LTEBand{ID: 40, Name: "TDD 2300", Mode: utils.TDDMode, StartEarfcnDl: 38650, CountEarfcn: 1000, StartFreqDl: 2300, LTEM: true}, *band)
+	assert.True(t, band.EarfcnDLInRange(39649))
+	assert.False(t, band.EarfcnDLInRange(39650))
+}
+
+func TestGetBandByIDError(t *testing.T) {
+	expectedErr := [...]int32{-1, 0, 5, 44}
+
+	for _, id := range expectedErr {
+		_, err := utils.GetBandByID(id)
+		assert.EqualError(t, err, "Invalid band: no matching definition")
+		assert.True(t, errors.Is(err, utils.ErrUnknownBand))
+	}
+}
+
+func TestGetBandRange(t *testing.T) {
+	expected := map[int32][2]int32{
+		1:  {0, 599},
+		2:  {600, 1199},
+		40: {38650, 39649},
+		43: {43590, 45589},
+	}
+
+	for id, rangeExpected := range expected {
+		min, max, err := utils.GetBandRange(id)
+		assert.NoError(t, err)
+		assert.Equal(t, rangeExpected[0], min)
+		assert.Equal(t, rangeExpected[1], max)
+
+		// The range must agree with GetBand at both ends
+		for _, earfcndl := range [...]int32{min, max} {
+			band, err := utils.GetBand(earfcndl)
+			assert.NoError(t, err)
+			assert.Equal(t, id, band.ID)
+		}
+		band, err := utils.GetBand(max + 1)
+		if err == nil {
+			assert.NotEqual(t, id, band.ID)
+		}
+	}
+
+	_, _, err := utils.GetBandRange(5)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+}
+
+func TestBandChannelCount(t *testing.T) {
+	expected := map[int32]int32{
+		1:  600,
+		2:  600,
+		32: 440,
+		41: 1940,
+		71: 350,
+	}
+
+	for id, countExpected := range expected {
+		count, err := utils.BandChannelCount(id)
+		assert.NoError(t, err)
+		assert.Equal(t, countExpected, count, "Band %d", id)
+
+		min, max, err := utils.BandChannelEndpoints(id)
+		assert.NoError(t, err)
+		assert.Equal(t, countExpected, max-min+1, "Band %d", id)
+	}
+
+	min, max, err := utils.BandChannelEndpoints(71)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(68586), min)
+	assert.Equal(t, int32(68935), max)
+
+	_, err = utils.BandChannelCount(5)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+	_, _, err = utils.BandChannelEndpoints(5)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+}
+
+func TestListBands(t *testing.T) {
+	bands := utils.ListBands()
+	assert.NotEmpty(t, bands)
+	for i := 1; i < len(bands); i++ {
+		assert.True(t, bands[i-1].ID < bands[i].ID)
+	}
+	for _, band := range bands {
+		fromID, err := utils.GetBandByID(band.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, *fromID, band)
+	}
+
+	// Mutating the returned slice must not change the package table
+	bands[0].CountEarfcn = 0
+	band, err := utils.GetBand(0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(600), band.CountEarfcn)
+	assert.Equal(t, int32(600), utils.ListBands()[0].CountEarfcn)
+}
+
+func TestGetDuplexMode(t *testing.T) {
+	expected := map[int32]utils.DuplexMode{
+		0:     utils.FDDMode,
+		600:   utils.FDDMode,
+		9920:  utils.SDLMode,
+		37750: utils.TDDMode,
+		38250: utils.TDDMode,
+		38650: utils.TDDMode,
+		39650: utils.TDDMode,
+		43590: utils.TDDMode,
 	}
+
+	for earfcndl, modeExpected := range expected {
+		mode, err := utils.GetDuplexMode(earfcndl)
+		assert.NoError(t, err)
+		assert.Equal(t, modeExpected, mode)
+	}
+
+	_, err := utils.GetDuplexMode(45590)
+	assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+}
+
+func TestGetUplinkEARFCN(t *testing.T) {
+	expected := map[int32]int32{
+		0:     18000,
+		599:   18599,
+		600:   18600,
+		1199:  19199,
+		6150:  24150,
+		9210:  27210,
+		9659:  27659,
+		65536: 131072,
+		66435: 131971,
+		68586: 133122,
+	}
+
+	for earfcndl, earfcnulExpected := range expected {
+		earfcnul, err := utils.GetUplinkEARFCN(earfcndl)
+		assert.NoError(t, err)
+		assert.Equal(t, earfcnulExpected, earfcnul)
+	}
+}
+
+func TestGetUplinkEARFCNError(t *testing.T) {
+	// SDL band (32), TDD bands (40, 43) and EARFCNs outside of any band
+	expectedErr := [...]int32{-1, 9920, 38650, 43590, 45589, 45590}
+
+	for _, earfcndl := range expectedErr {
+		_, err := utils.GetUplinkEARFCN(earfcndl)
+		assert.Error(t, err)
+	}
+}
+
+func TestFrequencyForEARFCNDL(t *testing.T) {
+	expected := map[int32]float64{
+		0:     2110.0,
+		599:   2169.9,
+		600:   1930.0,
+		1199:  1989.9,
+		38650: 2300.0,
+		45589: 3799.9,
+	}
+
+	for earfcndl, freqExpected := range expected {
+		freq, err := utils.FrequencyForEARFCNDL(earfcndl)
+		assert.NoError(t, err)
+		assert.InDelta(t, freqExpected, freq, 1e-9)
+	}
+}
+
+func TestFrequencyForEARFCNDLError(t *testing.T) {
+	expectedErr := [...]int32{-1, 45590, 45591}
+
+	for _, earfcndl := range expectedErr {
+		_, err := utils.FrequencyForEARFCNDL(earfcndl)
+		assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+	}
+}
+
+func TestEARFCNDLForFrequencyMHz(t *testing.T) {
+	expected := map[float64]int32{
+		2110.0: 0,
+		2169.9: 599,
+		1930.0: 600,
+		2300.0: 38650,
+		3799.9: 45589,
+		617.0:  68586,
+	}
+
+	for freq, earfcndlExpected := range expected {
+		earfcndl, err := utils.EARFCNDLForFrequencyMHz(freq)
+		assert.NoError(t, err)
+		assert.Equal(t, earfcndlExpected, earfcndl, "%.1f MHz", freq)
+
+		onRaster, err := utils.IsEARFCNOnRaster(earfcndl)
+		assert.NoError(t, err)
+		assert.True(t, onRaster)
+	}
+
+	// Round trip through the frequency, with float conversion error
+	freq, err := utils.FrequencyForEARFCNDL(1575)
+	assert.NoError(t, err)
+	earfcndl, err := utils.EARFCNDLForFrequencyMHz(freq + 1e-9)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1575), earfcndl)
+
+	_, err = utils.EARFCNDLForFrequencyMHz(2140.05)
+	assert.EqualError(t, err, "Invalid frequency 2140.050 MHz: not on the 100 kHz channel raster of Band 1")
+	_, err = utils.EARFCNDLForFrequencyMHz(2200)
+	assert.EqualError(t, err, "Invalid frequency 2200.0 MHz: no matching band")
+}
+
+func TestIsEARFCNOnRasterError(t *testing.T) {
+	_, err := utils.IsEARFCNOnRaster(45590)
+	assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+}
+
+func TestBandRasterKHz(t *testing.T) {
+	for _, id := range [...]int32{1, 32, 48, 71} {
+		raster, err := utils.BandRasterKHz(id)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(100), raster)
+	}
+
+	_, err := utils.BandRasterKHz(5)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+}
+
+func TestValidateEARFCNUL(t *testing.T) {
+	assert.NoError(t, utils.ValidateEARFCNUL(0, 18000))
+	assert.NoError(t, utils.ValidateEARFCNUL(0, 18599))
+	// The uplink channel does not have to be paired with the downlink one
+	assert.NoError(t, utils.ValidateEARFCNUL(300, 18000))
+	assert.NoError(t, utils.ValidateEARFCNUL(9659, 27659))
+
+	assert.EqualError(
+		t,
+		utils.ValidateEARFCNUL(0, 18600),
+		"EARFCNUL=18600 belongs to Band 2, but EARFCNDL=0 belongs to Band 1",
+	)
+	assert.EqualError(t, utils.ValidateEARFCNUL(0, 17999), "EARFCNUL=17999 invalid for Band 1 (18000, 18600)")
+	assert.EqualError(t, utils.ValidateEARFCNUL(38650, 38650), "Not a FDD Band: 40")
+	assert.EqualError(t, utils.ValidateEARFCNUL(9920, 27210), "Not a FDD Band: 32")
+	assert.EqualError(t, utils.ValidateEARFCNUL(45590, 18000), "Invalid EARFCNDL: no matching band")
+}
+
+func TestDuplexSpacingMHz(t *testing.T) {
+	expected := map[int32]float64{
+		1:  190,
+		2:  80,
+		3:  95,
+		4:  400,
+		28: 55,
+		65: 190,
+		71: -46,
+	}
+
+	for id, spacingExpected := range expected {
+		spacing, err := utils.DuplexSpacingMHz(id)
+		assert.NoError(t, err)
+		assert.Equal(t, spacingExpected, spacing)
+	}
+
+	_, err := utils.DuplexSpacingMHz(40)
+	assert.EqualError(t, err, "Not a FDD Band: 40")
+	_, err = utils.DuplexSpacingMHz(32)
+	assert.EqualError(t, err, "Not a FDD Band: 32")
+	_, err = utils.DuplexSpacingMHz(5)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+}
+
+func TestSupportsNBIoTAndLTEM(t *testing.T) {
+	expected := map[int32][2]bool{
+		1:  {true, true},
+		20: {true, true},
+		40: {false, true},
+		43: {true, false},
+		32: {false, false},
+		48: {false, false},
+	}
+
+	for id, supportExpected := range expected {
+		nbIoT, err := utils.SupportsNBIoT(id)
+		assert.NoError(t, err)
+		assert.Equal(t, supportExpected[0], nbIoT, "Band %d", id)
+		lteM, err := utils.SupportsLTEM(id)
+		assert.NoError(t, err)
+		assert.Equal(t, supportExpected[1], lteM, "Band %d", id)
+	}
+
+	_, err := utils.SupportsNBIoT(5)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+	_, err = utils.SupportsLTEM(5)
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+}
+
+func TestValidateTxPower(t *testing.T) {
+	assert.NoError(t, utils.ValidateTxPower(48, 30))
+	assert.NoError(t, utils.ValidateTxPower(48, 47))
+	assert.EqualError(t, utils.ValidateTxPower(48, 47.5), "Invalid transmit power: 47.5 dBm exceeds the 47.0 dBm cap of Band 48")
+
+	assert.NoError(t, utils.ValidateTxPower(40, 50))
+	assert.NoError(t, utils.ValidateTxPower(40, -30))
+	expectedErr := map[float64]string{
+		61:           "Invalid transmit power: 61.0 dBm, must be between -30 and 60 dBm",
+		-30.5:        "Invalid transmit power: -30.5 dBm, must be between -30 and 60 dBm",
+		-500:         "Invalid transmit power: -500.0 dBm, must be between -30 and 60 dBm",
+		math.Inf(1):  "Invalid transmit power: +Inf dBm, must be between -30 and 60 dBm",
+		math.Inf(-1): "Invalid transmit power: -Inf dBm, must be between -30 and 60 dBm",
+	}
+	for txPower, errExpected := range expectedErr {
+		assert.EqualError(t, utils.ValidateTxPower(40, txPower), errExpected)
+	}
+	assert.EqualError(t, utils.ValidateTxPower(48, math.NaN()), "Invalid transmit power: NaN dBm, must be between -30 and 60 dBm")
+
+	assert.EqualError(t, utils.ValidateTxPower(5, 20), "Invalid band: no matching definition")
 }
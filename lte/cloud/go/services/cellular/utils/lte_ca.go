@@ -0,0 +1,94 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CACombination is a carrier aggregation band combination (3GPP TS 36.101
+// Tables 5.6A.1-1 to 5.6A.1-3). Bands lists the band of each component
+// carrier in ascending order, so intra-band combinations repeat their band.
+type CACombination struct {
+	Name  string
+	Bands []int32
+}
+
+var caCombinations = [...]CACombination{
+	// Intra-band contiguous
+	{Name: "CA_40C", Bands: []int32{40, 40}},
+	{Name: "CA_41C", Bands: []int32{41, 41}},
+	// Inter-band
+	{Name: "CA_1A-3A", Bands: []int32{1, 3}},
+	{Name: "CA_1A-28A", Bands: []int32{1, 28}},
+	{Name: "CA_2A-4A", Bands: []int32{2, 4}},
+	{Name: "CA_3A-7A", Bands: []int32{3, 7}},
+	{Name: "CA_3A-20A", Bands: []int32{3, 20}},
+	{Name: "CA_7A-20A", Bands: []int32{7, 20}},
+	{Name: "CA_3A-7A-20A", Bands: []int32{3, 7, 20}},
+}
+
+// ValidateCACombination checks that the bands of a set of component carriers
+// form a supported carrier aggregation combination. The order of the bands
+// does not matter. A band may only appear more than once if an intra-band
+// combination is defined for it.
+func ValidateCACombination(bandIDs []int32) error {
+	if len(bandIDs) < 2 {
+		return fmt.Errorf("Invalid CA combination: at least 2 component carriers are required, got %d", len(bandIDs))
+	}
+	for _, id := range bandIDs {
+		if _, err := GetBandByID(id); err != nil {
+			return err
+		}
+	}
+
+	sorted := make([]int32, len(bandIDs))
+	copy(sorted, bandIDs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] && !hasIntraBandCombination(sorted[i]) {
+			return fmt.Errorf("Invalid CA combination: Band %d is repeated, but no intra-band combination is defined for it", sorted[i])
+		}
+	}
+
+	for _, combination := range caCombinations {
+		if equalBands(combination.Bands, sorted) {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid CA combination: bands %v are not a supported combination", sorted)
+}
+
+func hasIntraBandCombination(id int32) bool {
+	for _, combination := range caCombinations {
+		occurrences := 0
+		for _, band := range combination.Bands {
+			if band == id {
+				occurrences++
+			}
+		}
+		if occurrences > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func equalBands(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
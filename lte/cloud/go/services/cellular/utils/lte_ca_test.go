@@ -0,0 +1,47 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCACombination(t *testing.T) {
+	validCombinations := [][]int32{
+		{1, 3},
+		{3, 1},
+		{3, 7},
+		{20, 7, 3},
+		{40, 40},
+	}
+	for _, bandIDs := range validCombinations {
+		assert.NoError(t, utils.ValidateCACombination(bandIDs), "%v", bandIDs)
+	}
+
+	tests := []struct {
+		bandIDs     []int32
+		errExpected string
+	}{
+		{nil, "Invalid CA combination: at least 2 component carriers are required, got 0"},
+		{[]int32{1}, "Invalid CA combination: at least 2 component carriers are required, got 1"},
+		{[]int32{1, 5}, "Invalid band: no matching definition"},
+		{[]int32{1, 1}, "Invalid CA combination: Band 1 is repeated, but no intra-band combination is defined for it"},
+		{[]int32{3, 3, 7}, "Invalid CA combination: Band 3 is repeated, but no intra-band combination is defined for it"},
+		{[]int32{7, 1}, "Invalid CA combination: bands [1 7] are not a supported combination"},
+		{[]int32{40, 40, 40}, "Invalid CA combination: bands [40 40 40] are not a supported combination"},
+		{[]int32{1, 40}, "Invalid CA combination: bands [1 40] are not a supported combination"},
+	}
+	for _, test := range tests {
+		assert.EqualError(t, utils.ValidateCACombination(test.bandIDs), test.errExpected)
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// freqEpsilon absorbs floating point error when comparing frequencies in MHz
+const freqEpsilon = 1e-6
+
+// isFinite reports whether a value is neither NaN nor an infinity, either of
+// which would slip through range comparisons
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// prbsByBandwidthMHz maps the LTE channel bandwidths supported by the
+// cellular config to their number of resource blocks (3GPP TS 36.101
+// Table 5.6-1). 1.4 MHz (6 PRBs) is a legal LTE bandwidth but cannot be
+// expressed in whole MHz, so it is not included.
+var prbsByBandwidthMHz = map[int32]int32{
+	3:  15,
+	5:  25,
+	10: 50,
+	15: 75,
+	20: 100,
+}
+
+// validateBandwidth checks that a channel bandwidth is one of the LTE
+// bandwidths supported by the cellular config
+func validateBandwidth(bandwidthMHz int32) error {
+	_, err := BandwidthMHzToPRB(bandwidthMHz)
+	return err
+}
+
+// BandwidthMHzToPRB returns the number of resource blocks of a channel
+// bandwidth
+func BandwidthMHzToPRB(bwMHz int32) (int32, error) {
+	prb, ok := prbsByBandwidthMHz[bwMHz]
+	if !ok {
+		return 0, fmt.Errorf("Invalid bandwidth %d MHz: %w, must be one of 3, 5, 10, 15, 20", bwMHz, ErrInvalidBandwidth)
+	}
+	return prb, nil
+}
+
+// PRBToBandwidthMHz returns the channel bandwidth for a number of resource
+// blocks
+func PRBToBandwidthMHz(prb int32) (int32, error) {
+	for bwMHz, bwPRB := range prbsByBandwidthMHz {
+		if bwPRB == prb {
+			return bwMHz, nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid bandwidth %d PRBs: %w, must be one of 15, 25, 50, 75, 100", prb, ErrInvalidBandwidth)
+}
+
+// prbBandwidthMHz is the bandwidth of a resource block (12 subcarriers of
+// 15 kHz)
+const prbBandwidthMHz = 0.18
+
+// TransmissionBandwidthMHz returns the bandwidth occupied by the resource
+// blocks of a channel bandwidth, e.g. 18 MHz (100 PRBs) for a 20 MHz channel
+// (3GPP TS 36.101 Table 5.6-1)
+func TransmissionBandwidthMHz(bwMHz int32) (float64, error) {
+	prb, err := BandwidthMHzToPRB(bwMHz)
+	if err != nil {
+		return 0, err
+	}
+	return float64(prb) * prbBandwidthMHz, nil
+}
+
+// ValidateChannelFits checks that a carrier centered on an EARFCN-DL with the
+// given bandwidth stays within the downlink range of its band.
+func ValidateChannelFits(earfcndl int32, bandwidthMHz int32) error {
+	band, carrierLow, carrierHigh, err := carrierSpan(earfcndl, bandwidthMHz)
+	if err != nil {
+		return err
+	}
+	return validateCarrierInBand(band, earfcndl, bandwidthMHz, carrierLow, carrierHigh)
+}
+
+// validateCarrierInBand checks that a carrier spanning carrierLow-carrierHigh
+// stays within the downlink range of its band
+func validateCarrierInBand(band *LTEBand, earfcndl, bandwidthMHz int32, carrierLow, carrierHigh float64) error {
+	bandLow, bandHigh := band.StartFreqDl, band.endFreqDl()
+	if overflow := bandLow - carrierLow; overflow > freqEpsilon {
+		return fmt.Errorf(
+			"Carrier at EARFCNDL=%d with %d MHz bandwidth exceeds the lower edge of Band %d (%.1f-%.1f MHz) by %.1f MHz",
+			earfcndl, bandwidthMHz, band.ID, bandLow, bandHigh, overflow)
+	}
+	if overflow := carrierHigh - bandHigh; overflow > freqEpsilon {
+		return fmt.Errorf(
+			"Carrier at EARFCNDL=%d with %d MHz bandwidth exceeds the upper edge of Band %d (%.1f-%.1f MHz) by %.1f MHz",
+			earfcndl, bandwidthMHz, band.ID, bandLow, bandHigh, overflow)
+	}
+	return nil
+}
+
+// ValidateWithinLicensedBlock checks that a carrier centered on an EARFCN-DL
+// with the given bandwidth stays within the downlink range of its band (see
+// ValidateChannelFits) and within the licensed frequency block
+// [blockLowMHz, blockHighMHz], which must be finite and itself inside the
+// band. The carrier's span is its channel bandwidth.
+func ValidateWithinLicensedBlock(earfcndl, bandwidthMHz int32, blockLowMHz, blockHighMHz float64) error {
+	band, carrierLow, carrierHigh, err := carrierSpan(earfcndl, bandwidthMHz)
+	if err != nil {
+		return err
+	}
+	if err := validateCarrierInBand(band, earfcndl, bandwidthMHz, carrierLow, carrierHigh); err != nil {
+		return err
+	}
+	if !isFinite(blockLowMHz) || !isFinite(blockHighMHz) || blockHighMHz <= blockLowMHz {
+		return fmt.Errorf("Invalid licensed block: %.1f-%.1f MHz", blockLowMHz, blockHighMHz)
+	}
+	bandLow, bandHigh := band.StartFreqDl, band.endFreqDl()
+	if blockLowMHz < bandLow-freqEpsilon || blockHighMHz > bandHigh+freqEpsilon {
+		return fmt.Errorf(
+			"Licensed block %.1f-%.1f MHz is outside of Band %d (%.1f-%.1f MHz)",
+			blockLowMHz, blockHighMHz, band.ID, bandLow, bandHigh)
+	}
+	if carrierLow < blockLowMHz-freqEpsilon || carrierHigh > blockHighMHz+freqEpsilon {
+		return fmt.Errorf(
+			"Carrier at EARFCNDL=%d with %d MHz bandwidth (%.1f-%.1f MHz) is outside of the licensed block %.1f-%.1f MHz",
+			earfcndl, bandwidthMHz, carrierLow, carrierHigh, blockLowMHz, blockHighMHz)
+	}
+	return nil
+}
+
+// carrierSpan returns the band of a carrier centered on an EARFCN-DL with the
+// given bandwidth, and the lower and upper edge of the carrier in MHz. The
+// band is looked up once, so that callers checking the carrier against its
+// band see a single snapshot of the band table.
+func carrierSpan(earfcndl int32, bandwidthMHz int32) (*LTEBand, float64, float64, error) {
+	if err := validateBandwidth(bandwidthMHz); err != nil {
+		return nil, 0, 0, err
+	}
+	band, err := GetBand(earfcndl)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	center := band.freqDl(earfcndl)
+	halfBandwidth := float64(bandwidthMHz) / 2
+	return band, center - halfBandwidth, center + halfBandwidth, nil
+}
+
+// ChannelsOverlap checks whether the frequency spans of two carriers
+// intersect. Carriers that only touch at their edges do not overlap.
+func ChannelsOverlap(earfcndl1, bw1, earfcndl2, bw2 int32) (bool, error) {
+	spacing, err := AdjacentChannelSpacingMHz(earfcndl1, bw1, earfcndl2, bw2)
+	if err != nil {
+		return false, err
+	}
+	return spacing < -freqEpsilon, nil
+}
+
+// AdjacentChannelSpacingMHz returns the guard gap in MHz between the edges of
+// two carriers. The gap is negative if the carriers overlap.
+func AdjacentChannelSpacingMHz(earfcndl1, bw1, earfcndl2, bw2 int32) (float64, error) {
+	_, low1, high1, err := carrierSpan(earfcndl1, bw1)
+	if err != nil {
+		return 0, err
+	}
+	_, low2, high2, err := carrierSpan(earfcndl2, bw2)
+	if err != nil {
+		return 0, err
+	}
+	return spanSpacing(low1, high1, low2, high2), nil
+}
+
+// spanSpacing returns the gap between two frequency spans, negative if they
+// overlap
+func spanSpacing(low1, high1, low2, high2 float64) float64 {
+	if low1 <= low2 {
+		return low2 - high1
+	}
+	return low1 - high2
+}
+
+// EdgeGuardBandMHz returns the distance from the lower edge of a carrier to
+// FDL_low and from its upper edge to FDL_high. A negative guard band means
+// the carrier spills out of its band (see ValidateChannelFits).
+func EdgeGuardBandMHz(earfcndl, bandwidthMHz int32) (float64, float64, error) {
+	band, carrierLow, carrierHigh, err := carrierSpan(earfcndl, bandwidthMHz)
+	if err != nil {
+		return 0, 0, err
+	}
+	return carrierLow - band.StartFreqDl, band.endFreqDl() - carrierHigh, nil
+}
+
+// SuggestCenteredEARFCNDL returns the EARFCN-DL that centers a carrier of the
+// given bandwidth in a band. EARFCNs designate the center frequency of a
+// carrier, so this is the EARFCN-DL closest to the band's midpoint for which
+// the whole carrier still fits in the band.
+func SuggestCenteredEARFCNDL(bandID, bandwidthMHz int32) (int32, error) {
+	if err := validateBandwidth(bandwidthMHz); err != nil {
+		return 0, err
+	}
+	band, err := GetBandByID(bandID)
+	if err != nil {
+		return 0, err
+	}
+	if bandWidth := band.endFreqDl() - band.StartFreqDl; float64(bandwidthMHz) > bandWidth+freqEpsilon {
+		return 0, fmt.Errorf("%d MHz bandwidth does not fit in Band %d (%.1f MHz wide)", bandwidthMHz, band.ID, bandWidth)
+	}
+
+	center := band.StartEarfcnDl + band.CountEarfcn/2
+	halfBandwidth := float64(bandwidthMHz) / 2
+	for _, earfcndl := range [...]int32{center, center - 1, center + 1} {
+		carrierCenter := band.freqDl(earfcndl)
+		if validateCarrierInBand(band, earfcndl, bandwidthMHz, carrierCenter-halfBandwidth, carrierCenter+halfBandwidth) == nil {
+			return earfcndl, nil
+		}
+	}
+	return 0, fmt.Errorf("%d MHz bandwidth does not fit in Band %d", bandwidthMHz, band.ID)
+}
+
+// Carrier is one downlink carrier of a multi-carrier eNodeB
+type Carrier struct {
+	Earfcndl     int32
+	BandwidthMHz int32
+}
+
+// ValidateMultiCarrier checks that every carrier of an eNodeB belongs to a
+// band and has a valid bandwidth, and that no two carriers overlap. Bands may
+// share frequencies (e.g. 1 and 65), so carriers on different bands are
+// checked against each other as well. Errors name carriers by their index in
+// the list.
+func ValidateMultiCarrier(carriers []Carrier) []error {
+	type span struct {
+		band      *LTEBand
+		low, high float64
+	}
+	var errs []error
+	spans := make([]*span, len(carriers))
+	for i, carrier := range carriers {
+		band, err := GetBand(carrier.Earfcndl)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Carrier %d (EARFCNDL=%d): %w", i, carrier.Earfcndl, err))
+			continue
+		}
+		if err := validateBandwidth(carrier.BandwidthMHz); err != nil {
+			errs = append(errs, fmt.Errorf("Carrier %d (EARFCNDL=%d): %w", i, carrier.Earfcndl, err))
+			continue
+		}
+		center, halfBandwidth := band.freqDl(carrier.Earfcndl), float64(carrier.BandwidthMHz)/2
+		spans[i] = &span{band: band, low: center - halfBandwidth, high: center + halfBandwidth}
+	}
+
+	for i := range carriers {
+		for j := i + 1; j < len(carriers); j++ {
+			if spans[i] == nil || spans[j] == nil {
+				continue
+			}
+			if spanSpacing(spans[i].low, spans[i].high, spans[j].low, spans[j].high) >= -freqEpsilon {
+				continue
+			}
+			if spans[i].band.ID == spans[j].band.ID {
+				errs = append(errs, fmt.Errorf(
+					"Carriers %d (EARFCNDL=%d) and %d (EARFCNDL=%d) overlap in Band %d",
+					i, carriers[i].Earfcndl, j, carriers[j].Earfcndl, spans[i].band.ID))
+			} else {
+				errs = append(errs, fmt.Errorf(
+					"Carriers %d (EARFCNDL=%d, Band %d) and %d (EARFCNDL=%d, Band %d) overlap in frequency",
+					i, carriers[i].Earfcndl, spans[i].band.ID, j, carriers[j].Earfcndl, spans[j].band.ID))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,305 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthMHzToPRB(t *testing.T) {
+	expected := map[int32]int32{
+		3:  15,
+		5:  25,
+		10: 50,
+		15: 75,
+		20: 100,
+	}
+
+	for bwMHz, prbExpected := range expected {
+		prb, err := utils.BandwidthMHzToPRB(bwMHz)
+		assert.NoError(t, err)
+		assert.Equal(t, prbExpected, prb)
+
+		bw, err := utils.PRBToBandwidthMHz(prb)
+		assert.NoError(t, err)
+		assert.Equal(t, bwMHz, bw)
+	}
+
+	// 1.4 MHz (6 PRBs) cannot be expressed in whole MHz
+	_, err := utils.BandwidthMHzToPRB(1)
+	assert.EqualError(t, err, "Invalid bandwidth 1 MHz: unsupported channel bandwidth, must be one of 3, 5, 10, 15, 20")
+	assert.True(t, errors.Is(err, utils.ErrInvalidBandwidth))
+	_, err = utils.PRBToBandwidthMHz(6)
+	assert.EqualError(t, err, "Invalid bandwidth 6 PRBs: unsupported channel bandwidth, must be one of 15, 25, 50, 75, 100")
+	assert.True(t, errors.Is(err, utils.ErrInvalidBandwidth))
+	_, err = utils.PRBToBandwidthMHz(20)
+	assert.EqualError(t, err, "Invalid bandwidth 20 PRBs: unsupported channel bandwidth, must be one of 15, 25, 50, 75, 100")
+}
+
+func TestValidateWithinLicensedBlock(t *testing.T) {
+	// Band 3 is 1805-1880 MHz, the licensed block its lowest 20 MHz
+	assert.NoError(t, utils.ValidateWithinLicensedBlock(1300, 10, 1805, 1825))
+	assert.NoError(t, utils.ValidateWithinLicensedBlock(1300, 20, 1805, 1825))
+
+	// Spills past the upper edge of the block
+	assert.EqualError(
+		t,
+		utils.ValidateWithinLicensedBlock(1400, 10, 1805, 1825),
+		"Carrier at EARFCNDL=1400 with 10 MHz bandwidth (1820.0-1830.0 MHz) is outside of the licensed block 1805.0-1825.0 MHz",
+	)
+	// Block outside of the band
+	assert.EqualError(
+		t,
+		utils.ValidateWithinLicensedBlock(1900, 10, 1870, 1890),
+		"Licensed block 1870.0-1890.0 MHz is outside of Band 3 (1805.0-1880.0 MHz)",
+	)
+	// Carrier outside of the band
+	assert.EqualError(
+		t,
+		utils.ValidateWithinLicensedBlock(1200, 10, 1805, 1825),
+		"Carrier at EARFCNDL=1200 with 10 MHz bandwidth exceeds the lower edge of Band 3 (1805.0-1880.0 MHz) by 5.0 MHz",
+	)
+	assert.EqualError(
+		t,
+		utils.ValidateWithinLicensedBlock(1300, 10, 1825, 1805),
+		"Invalid licensed block: 1825.0-1805.0 MHz",
+	)
+	assert.EqualError(
+		t,
+		utils.ValidateWithinLicensedBlock(1300, 10, math.NaN(), math.NaN()),
+		"Invalid licensed block: NaN-NaN MHz",
+	)
+	assert.EqualError(
+		t,
+		utils.ValidateWithinLicensedBlock(1300, 10, 1805, math.NaN()),
+		"Invalid licensed block: 1805.0-NaN MHz",
+	)
+	assert.EqualError(
+		t,
+		utils.ValidateWithinLicensedBlock(1300, 10, math.Inf(-1), math.Inf(1)),
+		"Invalid licensed block: -Inf-+Inf MHz",
+	)
+}
+
+func TestTransmissionBandwidthMHz(t *testing.T) {
+	expected := map[int32]float64{
+		3:  2.7,
+		5:  4.5,
+		10: 9,
+		15: 13.5,
+		20: 18,
+	}
+
+	for bwMHz, bwExpected := range expected {
+		bw, err := utils.TransmissionBandwidthMHz(bwMHz)
+		assert.NoError(t, err)
+		assert.InDelta(t, bwExpected, bw, 1e-9, "%d MHz", bwMHz)
+	}
+
+	// 1.4 MHz (6 PRBs, 1.08 MHz) cannot be expressed in whole MHz
+	for _, bwMHz := range [...]int32{0, 1, 7} {
+		_, err := utils.TransmissionBandwidthMHz(bwMHz)
+		assert.True(t, errors.Is(err, utils.ErrInvalidBandwidth))
+	}
+}
+
+func TestValidateChannelFits(t *testing.T) {
+	// Band 1 is 2110-2170 MHz, EARFCNDL 0-599
+	assert.NoError(t, utils.ValidateChannelFits(100, 20))
+	assert.NoError(t, utils.ValidateChannelFits(499, 20))
+	assert.NoError(t, utils.ValidateChannelFits(15, 3))
+	assert.NoError(t, utils.ValidateChannelFits(300, 5))
+	// Band 40 is 2300-2400 MHz, EARFCNDL 38650-39649
+	assert.NoError(t, utils.ValidateChannelFits(39150, 20))
+
+	assert.EqualError(
+		t,
+		utils.ValidateChannelFits(50, 20),
+		"Carrier at EARFCNDL=50 with 20 MHz bandwidth exceeds the lower edge of Band 1 (2110.0-2170.0 MHz) by 5.0 MHz",
+	)
+	assert.EqualError(
+		t,
+		utils.ValidateChannelFits(599, 10),
+		"Carrier at EARFCNDL=599 with 10 MHz bandwidth exceeds the upper edge of Band 1 (2110.0-2170.0 MHz) by 4.9 MHz",
+	)
+}
+
+func TestValidateChannelFitsError(t *testing.T) {
+	for _, bandwidth := range [...]int32{0, 1, 4, 16, 40} {
+		err := utils.ValidateChannelFits(300, bandwidth)
+		assert.EqualError(t, err, fmt.Sprintf("Invalid bandwidth %d MHz: unsupported channel bandwidth, must be one of 3, 5, 10, 15, 20", bandwidth))
+		assert.True(t, errors.Is(err, utils.ErrInvalidBandwidth))
+	}
+	err := utils.ValidateChannelFits(45590, 20)
+	assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+	assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+}
+
+func TestChannelsOverlap(t *testing.T) {
+	// 2120 MHz and 2130 MHz with 10 MHz each touch at 2125 MHz
+	overlap, err := utils.ChannelsOverlap(100, 10, 200, 10)
+	assert.NoError(t, err)
+	assert.False(t, overlap)
+
+	// Same channel
+	overlap, err = utils.ChannelsOverlap(100, 10, 100, 10)
+	assert.NoError(t, err)
+	assert.True(t, overlap)
+
+	// 2120 MHz with 20 MHz and 2130 MHz with 10 MHz overlap by 5 MHz
+	overlap, err = utils.ChannelsOverlap(200, 10, 100, 20)
+	assert.NoError(t, err)
+	assert.True(t, overlap)
+
+	// Band 1 and band 2 are far apart
+	overlap, err = utils.ChannelsOverlap(300, 20, 900, 20)
+	assert.NoError(t, err)
+	assert.False(t, overlap)
+
+	_, err = utils.ChannelsOverlap(45590, 10, 100, 10)
+	assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+	_, err = utils.ChannelsOverlap(100, 10, 200, 7)
+	assert.EqualError(t, err, "Invalid bandwidth 7 MHz: unsupported channel bandwidth, must be one of 3, 5, 10, 15, 20")
+}
+
+func TestAdjacentChannelSpacingMHz(t *testing.T) {
+	spacing, err := utils.AdjacentChannelSpacingMHz(100, 10, 200, 10)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0, spacing, 1e-9)
+
+	spacing, err = utils.AdjacentChannelSpacingMHz(100, 5, 200, 5)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5.0, spacing, 1e-9)
+
+	// Argument order does not matter
+	spacing, err = utils.AdjacentChannelSpacingMHz(200, 5, 100, 5)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5.0, spacing, 1e-9)
+
+	spacing, err = utils.AdjacentChannelSpacingMHz(200, 10, 100, 20)
+	assert.NoError(t, err)
+	assert.InDelta(t, -5.0, spacing, 1e-9)
+
+	_, err = utils.AdjacentChannelSpacingMHz(100, 10, -1, 10)
+	assert.EqualError(t, err, "Invalid EARFCNDL: no matching band")
+}
+
+func TestEdgeGuardBandMHz(t *testing.T) {
+	// Band 40 is 2300-2400 MHz, 2350 MHz is centered
+	lower, upper, err := utils.EdgeGuardBandMHz(39150, 20)
+	assert.NoError(t, err)
+	assert.InDelta(t, 40.0, lower, 1e-9)
+	assert.InDelta(t, 40.0, upper, 1e-9)
+
+	// Band 1 is 2110-2170 MHz, 2120 MHz is against the lower edge
+	lower, upper, err = utils.EdgeGuardBandMHz(100, 20)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0, lower, 1e-9)
+	assert.InDelta(t, 40.0, upper, 1e-9)
+
+	// 2169.9 MHz spills past the upper edge
+	lower, upper, err = utils.EdgeGuardBandMHz(599, 10)
+	assert.NoError(t, err)
+	assert.InDelta(t, 54.9, lower, 1e-9)
+	assert.InDelta(t, -4.9, upper, 1e-9)
+
+	_, _, err = utils.EdgeGuardBandMHz(45590, 10)
+	assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+	_, _, err = utils.EdgeGuardBandMHz(100, 4)
+	assert.True(t, errors.Is(err, utils.ErrInvalidBandwidth))
+}
+
+func TestSuggestCenteredEARFCNDL(t *testing.T) {
+	// Band 40 is 2300-2400 MHz, centered at 2350 MHz
+	earfcndl, err := utils.SuggestCenteredEARFCNDL(40, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(39150), earfcndl)
+	freq, err := utils.FrequencyForEARFCNDL(earfcndl)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2350.0, freq, 1e-9)
+
+	// Band 1 is 2110-2170 MHz, centered at 2140 MHz
+	earfcndl, err = utils.SuggestCenteredEARFCNDL(1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(300), earfcndl)
+
+	for _, band := range utils.ListBands() {
+		for _, bandwidth := range [...]int32{3, 5, 10, 15, 20} {
+			earfcndl, err := utils.SuggestCenteredEARFCNDL(band.ID, bandwidth)
+			assert.NoError(t, err)
+			assert.NoError(t, utils.ValidateChannelFits(earfcndl, bandwidth))
+		}
+	}
+}
+
+func TestSuggestCenteredEARFCNDLError(t *testing.T) {
+	_, err := utils.SuggestCenteredEARFCNDL(40, 7)
+	assert.True(t, errors.Is(err, utils.ErrInvalidBandwidth))
+	_, err = utils.SuggestCenteredEARFCNDL(5, 20)
+	assert.True(t, errors.Is(err, utils.ErrUnknownBand))
+
+	defer utils.ResetBandTable()
+	err = utils.LoadBandTable(strings.NewReader(
+		`[{"id": 40, "duplex": "TDD", "earfcndl_min": 38650, "earfcndl_max": 38749, "dl_low_mhz": 2300}]`))
+	assert.NoError(t, err)
+	_, err = utils.SuggestCenteredEARFCNDL(40, 20)
+	assert.EqualError(t, err, "20 MHz bandwidth does not fit in Band 40 (10.0 MHz wide)")
+	earfcndl, err := utils.SuggestCenteredEARFCNDL(40, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(38700), earfcndl)
+}
+
+func TestValidateMultiCarrier(t *testing.T) {
+	// Adjacent carriers on the same band and a carrier on another band
+	assert.Empty(t, utils.ValidateMultiCarrier([]utils.Carrier{
+		{Earfcndl: 38750, BandwidthMHz: 20},
+		{Earfcndl: 38950, BandwidthMHz: 20},
+		{Earfcndl: 1575, BandwidthMHz: 10},
+	}))
+
+	errs := utils.ValidateMultiCarrier([]utils.Carrier{
+		{Earfcndl: 38750, BandwidthMHz: 20},
+		{Earfcndl: 38800, BandwidthMHz: 20},
+		{Earfcndl: 1575, BandwidthMHz: 10},
+		{Earfcndl: 45590, BandwidthMHz: 10},
+		{Earfcndl: 38750, BandwidthMHz: 7},
+	})
+	assert.Equal(
+		t,
+		[]string{
+			"Carrier 3 (EARFCNDL=45590): Invalid EARFCNDL: no matching band",
+			"Carrier 4 (EARFCNDL=38750): Invalid bandwidth 7 MHz: unsupported channel bandwidth, must be one of 3, 5, 10, 15, 20",
+			"Carriers 0 (EARFCNDL=38750) and 1 (EARFCNDL=38800) overlap in Band 40",
+		},
+		errorStrings(errs),
+	)
+
+	// Bands 1 and 65 share 2110-2170 MHz, both carriers are at 2120 MHz
+	overlap, err := utils.ChannelsOverlap(100, 20, 65636, 20)
+	assert.NoError(t, err)
+	assert.True(t, overlap)
+	errs = utils.ValidateMultiCarrier([]utils.Carrier{
+		{Earfcndl: 100, BandwidthMHz: 20},
+		{Earfcndl: 65636, BandwidthMHz: 20},
+		{Earfcndl: 65936, BandwidthMHz: 10},
+	})
+	assert.Equal(
+		t,
+		[]string{"Carriers 0 (EARFCNDL=100, Band 1) and 1 (EARFCNDL=65636, Band 65) overlap in frequency"},
+		errorStrings(errs),
+	)
+}
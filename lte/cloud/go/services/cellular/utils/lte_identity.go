@@ -0,0 +1,114 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const (
+	mccLength = 3
+	maxTAC    = 0xFFFF
+
+	// An E-UTRAN Cell Identity is a 20-bit eNodeB ID followed by an 8-bit
+	// cell ID
+	cellIDBits = 8
+	maxCellID  = 1<<cellIDBits - 1
+	maxEnbID   = 1<<20 - 1
+	maxECI     = 1<<28 - 1
+	// ECIs are written as 7 hex digits in an ECGI
+	eciHexLength = 7
+)
+
+var plmnRe = regexp.MustCompile("^[0-9]*$")
+
+// ValidatePLMN checks that a PLMN ID is a 3-digit MCC followed by a 2- or
+// 3-digit MNC
+func ValidatePLMN(plmn string) error {
+	_, _, err := SplitPLMN(plmn)
+	return err
+}
+
+// SplitPLMN splits a 5- or 6-digit PLMN ID into its MCC and MNC
+func SplitPLMN(plmn string) (string, string, error) {
+	if !plmnRe.MatchString(plmn) {
+		return "", "", fmt.Errorf("Invalid PLMN: %q must only contain digits", plmn)
+	}
+	if len(plmn) != 5 && len(plmn) != 6 {
+		return "", "", fmt.Errorf("Invalid PLMN: %q must be 5 or 6 digits, got %d", plmn, len(plmn))
+	}
+	return plmn[:mccLength], plmn[mccLength:], nil
+}
+
+// ValidateTAC checks that a tracking area code fits in 16 bits and is not
+// one of the reserved values 0x0000, 0xFFFE and 0xFFFF
+func ValidateTAC(tac int32) error {
+	if tac < 0 || tac > maxTAC {
+		return fmt.Errorf("Invalid TAC: %d, must be between 1 and 65533", tac)
+	}
+	switch tac {
+	case 0x0000, 0xFFFE, 0xFFFF:
+		return fmt.Errorf("Invalid TAC: %d is reserved", tac)
+	}
+	return nil
+}
+
+// SplitECI splits a 28-bit E-UTRAN Cell Identity into its eNodeB ID and
+// cell ID
+func SplitECI(eci int32) (int32, int32, error) {
+	if eci < 0 || eci > maxECI {
+		return 0, 0, fmt.Errorf("Invalid ECI: %d, must fit in 28 bits", eci)
+	}
+	return eci >> cellIDBits, eci & maxCellID, nil
+}
+
+// ComposeECI builds a 28-bit E-UTRAN Cell Identity from an eNodeB ID and
+// a cell ID
+func ComposeECI(enbID, cellID int32) (int32, error) {
+	if enbID < 0 || enbID > maxEnbID {
+		return 0, fmt.Errorf("Invalid eNodeB ID: %d, must fit in 20 bits", enbID)
+	}
+	if cellID < 0 || cellID > maxCellID {
+		return 0, fmt.Errorf("Invalid cell ID: %d, must fit in 8 bits", cellID)
+	}
+	return enbID<<cellIDBits | cellID, nil
+}
+
+// BuildECGI builds an E-UTRAN Cell Global Identifier from a PLMN ID and an
+// ECI. The ECGI is the PLMN ID digits followed by the ECI as 7 upper case
+// hex digits, e.g. "00101" and 0x1A2B3C4 give "001011A2B3C4".
+func BuildECGI(plmn string, eci int32) (string, error) {
+	if err := ValidatePLMN(plmn); err != nil {
+		return "", err
+	}
+	if _, _, err := SplitECI(eci); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%0*X", plmn, eciHexLength, eci), nil
+}
+
+// ParseECGI splits an ECGI built by BuildECGI into its PLMN ID and ECI. The
+// length of the ECGI (12 or 13 characters) tells the 2- and 3-digit MNC
+// apart.
+func ParseECGI(ecgi string) (string, int32, error) {
+	if len(ecgi) != 5+eciHexLength && len(ecgi) != 6+eciHexLength {
+		return "", 0, fmt.Errorf("Invalid ECGI: %q must be 12 or 13 characters, got %d", ecgi, len(ecgi))
+	}
+	plmn, eciHex := ecgi[:len(ecgi)-eciHexLength], ecgi[len(ecgi)-eciHexLength:]
+	if err := ValidatePLMN(plmn); err != nil {
+		return "", 0, fmt.Errorf("Invalid ECGI: %q: %s", ecgi, err)
+	}
+	eci, err := strconv.ParseUint(eciHex, 16, 28)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid ECGI: %q must end with a 7 digit hex ECI", ecgi)
+	}
+	return plmn, int32(eci), nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPLMN(t *testing.T) {
+	expected := map[string][2]string{
+		"00101":  {"001", "01"},
+		"310410": {"310", "410"},
+		"46000":  {"460", "00"},
+		"001001": {"001", "001"},
+	}
+
+	for plmn, mccMncExpected := range expected {
+		mcc, mnc, err := utils.SplitPLMN(plmn)
+		assert.NoError(t, err)
+		assert.Equal(t, mccMncExpected[0], mcc)
+		assert.Equal(t, mccMncExpected[1], mnc)
+		assert.NoError(t, utils.ValidatePLMN(plmn))
+	}
+}
+
+func TestSplitPLMNError(t *testing.T) {
+	expectedErr := map[string]string{
+		"":        `Invalid PLMN: "" must be 5 or 6 digits, got 0`,
+		"0010":    `Invalid PLMN: "0010" must be 5 or 6 digits, got 4`,
+		"0010011": `Invalid PLMN: "0010011" must be 5 or 6 digits, got 7`,
+		"31041a":  `Invalid PLMN: "31041a" must only contain digits`,
+		"310-41":  `Invalid PLMN: "310-41" must only contain digits`,
+		" 31041":  `Invalid PLMN: " 31041" must only contain digits`,
+	}
+
+	for plmn, errExpected := range expectedErr {
+		_, _, err := utils.SplitPLMN(plmn)
+		assert.EqualError(t, err, errExpected)
+		assert.EqualError(t, utils.ValidatePLMN(plmn), errExpected)
+	}
+}
+
+func TestValidateTAC(t *testing.T) {
+	for _, tac := range [...]int32{1, 2, 1000, 65533} {
+		assert.NoError(t, utils.ValidateTAC(tac))
+	}
+
+	expectedErr := map[int32]string{
+		0:     "Invalid TAC: 0 is reserved",
+		65534: "Invalid TAC: 65534 is reserved",
+		65535: "Invalid TAC: 65535 is reserved",
+		65536: "Invalid TAC: 65536, must be between 1 and 65533",
+		-1:    "Invalid TAC: -1, must be between 1 and 65533",
+	}
+	for tac, errExpected := range expectedErr {
+		assert.EqualError(t, utils.ValidateTAC(tac), errExpected)
+	}
+}
+
+func TestSplitECI(t *testing.T) {
+	expected := map[int32][2]int32{
+		0:         {0, 0},
+		0x1:       {0, 1},
+		0x100:     {1, 0},
+		0x12345AB: {0x12345, 0xAB},
+		0xFFFFFFF: {0xFFFFF, 0xFF},
+		0xFFFFF00: {0xFFFFF, 0},
+		0x00000FF: {0, 0xFF},
+	}
+
+	for eci, idsExpected := range expected {
+		enbID, cellID, err := utils.SplitECI(eci)
+		assert.NoError(t, err)
+		assert.Equal(t, idsExpected[0], enbID)
+		assert.Equal(t, idsExpected[1], cellID)
+
+		composed, err := utils.ComposeECI(enbID, cellID)
+		assert.NoError(t, err)
+		assert.Equal(t, eci, composed)
+	}
+
+	_, _, err := utils.SplitECI(0x10000000)
+	assert.EqualError(t, err, "Invalid ECI: 268435456, must fit in 28 bits")
+	_, _, err = utils.SplitECI(-1)
+	assert.EqualError(t, err, "Invalid ECI: -1, must fit in 28 bits")
+}
+
+func TestComposeECIError(t *testing.T) {
+	_, err := utils.ComposeECI(0x100000, 0)
+	assert.EqualError(t, err, "Invalid eNodeB ID: 1048576, must fit in 20 bits")
+	_, err = utils.ComposeECI(-1, 0)
+	assert.EqualError(t, err, "Invalid eNodeB ID: -1, must fit in 20 bits")
+	_, err = utils.ComposeECI(0, 0x100)
+	assert.EqualError(t, err, "Invalid cell ID: 256, must fit in 8 bits")
+	_, err = utils.ComposeECI(0, -1)
+	assert.EqualError(t, err, "Invalid cell ID: -1, must fit in 8 bits")
+}
+
+func TestBuildECGI(t *testing.T) {
+	expected := []struct {
+		plmn string
+		eci  int32
+		ecgi string
+	}{
+		{"00101", 0x1A2B3C4, "001011A2B3C4"},
+		{"310410", 0x0000101, "3104100000101"},
+		{"310410", 0, "3104100000000"},
+		{"00101", 0xFFFFFFF, "00101FFFFFFF"},
+	}
+
+	for _, test := range expected {
+		ecgi, err := utils.BuildECGI(test.plmn, test.eci)
+		assert.NoError(t, err)
+		assert.Equal(t, test.ecgi, ecgi)
+
+		plmn, eci, err := utils.ParseECGI(ecgi)
+		assert.NoError(t, err)
+		assert.Equal(t, test.plmn, plmn)
+		assert.Equal(t, test.eci, eci)
+	}
+
+	_, err := utils.BuildECGI("0010", 1)
+	assert.EqualError(t, err, `Invalid PLMN: "0010" must be 5 or 6 digits, got 4`)
+	_, err = utils.BuildECGI("00101", 0x10000000)
+	assert.EqualError(t, err, "Invalid ECI: 268435456, must fit in 28 bits")
+}
+
+func TestParseECGIError(t *testing.T) {
+	expectedErr := map[string]string{
+		"":               `Invalid ECGI: "" must be 12 or 13 characters, got 0`,
+		"001011A2B3C":    `Invalid ECGI: "001011A2B3C" must be 12 or 13 characters, got 11`,
+		"3104101A2B3C4F": `Invalid ECGI: "3104101A2B3C4F" must be 12 or 13 characters, got 14`,
+		"0010A1A2B3C4":   `Invalid ECGI: "0010A1A2B3C4": Invalid PLMN: "0010A" must only contain digits`,
+		"001011A2B3CG":   `Invalid ECGI: "001011A2B3CG" must end with a 7 digit hex ECI`,
+		"00101-A2B3C4":   `Invalid ECGI: "00101-A2B3C4" must end with a 7 digit hex ECI`,
+	}
+
+	for ecgi, errExpected := range expectedErr {
+		_, _, err := utils.ParseECGI(ecgi)
+		assert.EqualError(t, err, errExpected)
+	}
+}
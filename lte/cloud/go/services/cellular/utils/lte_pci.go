@@ -0,0 +1,181 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import "fmt"
+
+const (
+	minPCI = 0
+	maxPCI = 503
+)
+
+// PCIConflictKind describes why a set of PCIs conflict
+type PCIConflictKind int32
+
+const (
+	// PCIOutOfRange - PCI is outside of 0-503
+	PCIOutOfRange PCIConflictKind = iota
+	// PCIDuplicate - the same PCI is used more than once
+	PCIDuplicate
+	// PCIMod3Collision - PCIs share a PSS sequence (PCI mod 3)
+	PCIMod3Collision
+	// PCIMod30Collision - PCIs share an uplink reference signal group
+	// (PCI mod 30)
+	PCIMod30Collision
+)
+
+// PCIConflict is a conflict between one or more PCIs in a neighbor list
+type PCIConflict struct {
+	Kind PCIConflictKind
+	PCIs []int32
+}
+
+func pciInRange(pci int32) bool {
+	return minPCI <= pci && pci <= maxPCI
+}
+
+// ValidatePCIList checks a list of neighbor PCIs and returns every conflict
+// found: out of range PCIs (one conflict each), PCIs used more than once, and
+// groups of distinct PCIs that collide mod 3 or mod 30. Conflicts are ordered
+// by kind, then by the first appearance of their PCIs in the list.
+func ValidatePCIList(pcis []int32) []PCIConflict {
+	var conflicts []PCIConflict
+	var distinct []int32
+	occurrences := map[int32]int{}
+	for _, pci := range pcis {
+		if !pciInRange(pci) {
+			conflicts = append(conflicts, PCIConflict{Kind: PCIOutOfRange, PCIs: []int32{pci}})
+			continue
+		}
+		if occurrences[pci] == 0 {
+			distinct = append(distinct, pci)
+		}
+		occurrences[pci]++
+	}
+
+	for _, pci := range distinct {
+		if count := occurrences[pci]; count > 1 {
+			duplicates := make([]int32, count)
+			for i := range duplicates {
+				duplicates[i] = pci
+			}
+			conflicts = append(conflicts, PCIConflict{Kind: PCIDuplicate, PCIs: duplicates})
+		}
+	}
+	conflicts = append(conflicts, moduloCollisions(distinct, 3, PCIMod3Collision)...)
+	conflicts = append(conflicts, moduloCollisions(distinct, 30, PCIMod30Collision)...)
+	return conflicts
+}
+
+// moduloCollisions groups distinct PCIs by PCI mod modulus and returns a
+// conflict for every group with more than one PCI
+func moduloCollisions(distinct []int32, modulus int32, kind PCIConflictKind) []PCIConflict {
+	var order []int32
+	groups := map[int32][]int32{}
+	for _, pci := range distinct {
+		key := pci % modulus
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], pci)
+	}
+
+	var conflicts []PCIConflict
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			conflicts = append(conflicts, PCIConflict{Kind: kind, PCIs: groups[key]})
+		}
+	}
+	return conflicts
+}
+
+// CellNeighbors is a cell and the IDs of its neighbor cells, for AssignPCIs
+type CellNeighbors struct {
+	ID        string
+	Neighbors []string
+}
+
+// AssignPCIs assigns a PCI from the available pool to every cell so that no
+// two neighbor cells share a PCI. Neighbor relations are symmetric, and
+// neighbors that are not in the list of cells are ignored.
+//
+// Cells are assigned greedily in list order, each getting the first PCI of
+// the pool that no neighbor uses and that does not collide mod 3 with a
+// neighbor, or if there is none, the first PCI that no neighbor uses. The
+// result only depends on the order of the cells and of the pool.
+func AssignPCIs(cells []CellNeighbors, available []int32) (map[string]int32, error) {
+	for _, conflict := range ValidatePCIList(available) {
+		switch conflict.Kind {
+		case PCIOutOfRange:
+			return nil, fmt.Errorf("Invalid PCI pool: PCI %d must be between %d and %d", conflict.PCIs[0], minPCI, maxPCI)
+		case PCIDuplicate:
+			return nil, fmt.Errorf("Invalid PCI pool: PCI %d is listed more than once", conflict.PCIs[0])
+		}
+	}
+
+	neighbors := map[string]map[string]bool{}
+	for _, cell := range cells {
+		if _, ok := neighbors[cell.ID]; ok {
+			return nil, fmt.Errorf("Cell %q is defined more than once", cell.ID)
+		}
+		neighbors[cell.ID] = map[string]bool{}
+	}
+	for _, cell := range cells {
+		for _, neighbor := range cell.Neighbors {
+			if _, ok := neighbors[neighbor]; ok && neighbor != cell.ID {
+				neighbors[cell.ID][neighbor] = true
+				neighbors[neighbor][cell.ID] = true
+			}
+		}
+	}
+
+	assigned := make(map[string]int32, len(cells))
+	for _, cell := range cells {
+		var usedPCIs []int32
+		for neighbor := range neighbors[cell.ID] {
+			if pci, ok := assigned[neighbor]; ok {
+				usedPCIs = append(usedPCIs, pci)
+			}
+		}
+		pci, ok := pickPCI(available, usedPCIs)
+		if !ok {
+			return nil, fmt.Errorf("PCI pool exhausted: no PCI left for cell %q", cell.ID)
+		}
+		assigned[cell.ID] = pci
+	}
+	return assigned, nil
+}
+
+// pickPCI returns the first PCI of the pool that is not used and does not
+// collide mod 3 with a used PCI, or else the first PCI that is not used
+func pickPCI(available []int32, usedPCIs []int32) (int32, bool) {
+	fallback, hasFallback := int32(0), false
+	for _, pci := range available {
+		conflict, mod3Collision := false, false
+		for _, used := range usedPCIs {
+			if pci == used {
+				conflict = true
+				break
+			}
+			if pci%3 == used%3 {
+				mod3Collision = true
+			}
+		}
+		if conflict {
+			continue
+		}
+		if !mod3Collision {
+			return pci, true
+		}
+		if !hasFallback {
+			fallback, hasFallback = pci, true
+		}
+	}
+	return fallback, hasFallback
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePCIList(t *testing.T) {
+	assert.Empty(t, utils.ValidatePCIList(nil))
+	assert.Empty(t, utils.ValidatePCIList([]int32{0, 1, 2}))
+	assert.Empty(t, utils.ValidatePCIList([]int32{503}))
+
+	conflicts := utils.ValidatePCIList([]int32{1, 4, 504, 1, -1, 34, 2})
+	assert.Equal(
+		t,
+		[]utils.PCIConflict{
+			{Kind: utils.PCIOutOfRange, PCIs: []int32{504}},
+			{Kind: utils.PCIOutOfRange, PCIs: []int32{-1}},
+			{Kind: utils.PCIDuplicate, PCIs: []int32{1, 1}},
+			{Kind: utils.PCIMod3Collision, PCIs: []int32{1, 4, 34}},
+			{Kind: utils.PCIMod30Collision, PCIs: []int32{4, 34}},
+		},
+		conflicts,
+	)
+}
+
+func TestAssignPCIs(t *testing.T) {
+	// A, B and C are all neighbors of each other, D only neighbors A and E
+	// has no neighbors. Relations only listed on one side still apply.
+	cells := []utils.CellNeighbors{
+		{ID: "A", Neighbors: []string{"B", "C"}},
+		{ID: "B", Neighbors: []string{"C"}},
+		{ID: "C"},
+		{ID: "D", Neighbors: []string{"A", "external"}},
+		{ID: "E"},
+	}
+
+	assigned, err := utils.AssignPCIs(cells, []int32{0, 1, 2, 3, 4, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int32{"A": 0, "B": 1, "C": 2, "D": 1, "E": 0}, assigned)
+
+	// The assignment must be conflict free for every cell and its neighbors
+	for _, group := range [][]string{{"A", "B", "C"}, {"A", "D"}} {
+		var pcis []int32
+		for _, id := range group {
+			pcis = append(pcis, assigned[id])
+		}
+		assert.Empty(t, utils.ValidatePCIList(pcis), "Cells %v", group)
+	}
+
+	// Without enough PCIs of distinct mod 3 values, neighbors only avoid
+	// sharing a PCI
+	assigned, err = utils.AssignPCIs(cells, []int32{0, 3, 6})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int32{"A": 0, "B": 3, "C": 6, "D": 3, "E": 0}, assigned)
+}
+
+func TestAssignPCIsError(t *testing.T) {
+	triangle := []utils.CellNeighbors{
+		{ID: "A", Neighbors: []string{"B", "C"}},
+		{ID: "B", Neighbors: []string{"A", "C"}},
+		{ID: "C", Neighbors: []string{"A", "B"}},
+	}
+
+	_, err := utils.AssignPCIs(triangle, []int32{0, 1})
+	assert.EqualError(t, err, `PCI pool exhausted: no PCI left for cell "C"`)
+	_, err = utils.AssignPCIs(triangle, nil)
+	assert.EqualError(t, err, `PCI pool exhausted: no PCI left for cell "A"`)
+	_, err = utils.AssignPCIs(triangle, []int32{0, 1, 504})
+	assert.EqualError(t, err, "Invalid PCI pool: PCI 504 must be between 0 and 503")
+	_, err = utils.AssignPCIs(triangle, []int32{0, 1, 1})
+	assert.EqualError(t, err, "Invalid PCI pool: PCI 1 is listed more than once")
+	_, err = utils.AssignPCIs(append(triangle, utils.CellNeighbors{ID: "A"}), []int32{0, 1, 2})
+	assert.EqualError(t, err, `Cell "A" is defined more than once`)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// bandsByRegion lists the bands that may be deployed in each regulatory
+// region, keyed by upper case region code
+var bandsByRegion = map[string][]int32{
+	"EU": {1, 3, 7, 20, 28, 32, 38, 40, 42, 43},
+	"JP": {1, 3, 28, 41, 42},
+	"US": {2, 4, 41, 48, 71},
+}
+
+func getRegionBands(region string) ([]int32, error) {
+	ids, ok := bandsByRegion[strings.ToUpper(region)]
+	if !ok {
+		var regions []string
+		for known := range bandsByRegion {
+			regions = append(regions, known)
+		}
+		sort.Strings(regions)
+		return nil, fmt.Errorf("Invalid region: %q, must be one of %s", region, strings.Join(regions, ", "))
+	}
+	return ids, nil
+}
+
+// BandsForRegion returns the bands of the band table that are allowed in a
+// region, sorted by band number. Region codes are case insensitive.
+func BandsForRegion(region string) ([]LTEBand, error) {
+	ids, err := getRegionBands(region)
+	if err != nil {
+		return nil, err
+	}
+	var ret []LTEBand
+	for _, band := range ListBands() {
+		for _, id := range ids {
+			if band.ID == id {
+				ret = append(ret, band)
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+// IsBandAllowedInRegion checks whether a band may be deployed in a region
+func IsBandAllowedInRegion(bandID int32, region string) (bool, error) {
+	ids, err := getRegionBands(region)
+	if err != nil {
+		return false, err
+	}
+	if _, err := GetBandByID(bandID); err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if id == bandID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
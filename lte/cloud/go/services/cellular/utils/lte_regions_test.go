@@ -0,0 +1,61 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandsForRegion(t *testing.T) {
+	bands, err := utils.BandsForRegion("US")
+	assert.NoError(t, err)
+	var ids []int32
+	for _, band := range bands {
+		ids = append(ids, band.ID)
+	}
+	assert.Equal(t, []int32{2, 4, 41, 48, 71}, ids)
+
+	bands, err = utils.BandsForRegion("jp")
+	assert.NoError(t, err)
+	assert.Len(t, bands, 5)
+
+	_, err = utils.BandsForRegion("XX")
+	assert.EqualError(t, err, `Invalid region: "XX", must be one of EU, JP, US`)
+}
+
+func TestIsBandAllowedInRegion(t *testing.T) {
+	expected := []struct {
+		bandID  int32
+		region  string
+		allowed bool
+	}{
+		{48, "US", true},
+		{48, "EU", false},
+		{20, "EU", true},
+		{20, "US", false},
+		{1, "eu", true},
+		{1, "JP", true},
+		{1, "US", false},
+	}
+
+	for _, test := range expected {
+		allowed, err := utils.IsBandAllowedInRegion(test.bandID, test.region)
+		assert.NoError(t, err)
+		assert.Equal(t, test.allowed, allowed, "Band %d in %s", test.bandID, test.region)
+	}
+
+	_, err := utils.IsBandAllowedInRegion(1, "")
+	assert.EqualError(t, err, `Invalid region: "", must be one of EU, JP, US`)
+	_, err = utils.IsBandAllowedInRegion(5, "US")
+	assert.EqualError(t, err, "Invalid band: no matching definition")
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import "fmt"
+
+// RFConfig holds the RF parameters of a cell. SubframeAssignment and
+// SpecialSubframePattern only apply to TDD bands and must be left 0 otherwise.
+type RFConfig struct {
+	Earfcndl               int32
+	BandwidthMHz           int32
+	PCI                    int32
+	TAC                    int32
+	SubframeAssignment     int32
+	SpecialSubframePattern int32
+}
+
+// ValidateCellularRFConfig validates all RF parameters of a cell together and
+// returns every problem found instead of stopping at the first one
+func ValidateCellularRFConfig(cfg RFConfig) []error {
+	var errs []error
+
+	band, err := GetBand(cfg.Earfcndl)
+	if err != nil {
+		errs = append(errs, err)
+		if err := validateBandwidth(cfg.BandwidthMHz); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		if err := ValidateChannelFits(cfg.Earfcndl, cfg.BandwidthMHz); err != nil {
+			errs = append(errs, err)
+		}
+		// A supplemental downlink band has no uplink and cannot carry a cell
+		if band.Mode == SDLMode {
+			errs = append(errs, fmt.Errorf("Not a FDD or TDD Band: %d is a supplemental downlink (SDL) band", band.ID))
+		}
+		if band.Mode == TDDMode {
+			if err := validateSubframeAssignment(cfg.SubframeAssignment); err != nil {
+				errs = append(errs, err)
+			}
+			if err := validateSpecialSubframePattern(cfg.SpecialSubframePattern); err != nil {
+				errs = append(errs, err)
+			}
+		} else if cfg.SubframeAssignment != 0 || cfg.SpecialSubframePattern != 0 {
+			errs = append(errs, fmt.Errorf(
+				"Not a TDD Band: %d, subframe assignment and special subframe pattern must not be set", band.ID))
+		}
+	}
+
+	if !pciInRange(cfg.PCI) {
+		errs = append(errs, fmt.Errorf("Invalid PCI: %d, must be between %d and %d", cfg.PCI, minPCI, maxPCI))
+	}
+	if err := ValidateTAC(cfg.TAC); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
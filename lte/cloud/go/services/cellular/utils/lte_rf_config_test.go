@@ -0,0 +1,110 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func errorStrings(errs []error) []string {
+	var ret []string
+	for _, err := range errs {
+		ret = append(ret, err.Error())
+	}
+	return ret
+}
+
+func TestValidateCellularRFConfig(t *testing.T) {
+	// FDD
+	assert.Empty(t, utils.ValidateCellularRFConfig(utils.RFConfig{
+		Earfcndl:     300,
+		BandwidthMHz: 20,
+		PCI:          260,
+		TAC:          1,
+	}))
+	// TDD
+	assert.Empty(t, utils.ValidateCellularRFConfig(utils.RFConfig{
+		Earfcndl:               44590,
+		BandwidthMHz:           20,
+		PCI:                    0,
+		TAC:                    65533,
+		SubframeAssignment:     2,
+		SpecialSubframePattern: 7,
+	}))
+}
+
+func TestValidateCellularRFConfigError(t *testing.T) {
+	errs := utils.ValidateCellularRFConfig(utils.RFConfig{
+		Earfcndl:           599,
+		BandwidthMHz:       20,
+		PCI:                504,
+		TAC:                0,
+		SubframeAssignment: 2,
+	})
+	assert.Equal(
+		t,
+		[]string{
+			"Carrier at EARFCNDL=599 with 20 MHz bandwidth exceeds the upper edge of Band 1 (2110.0-2170.0 MHz) by 9.9 MHz",
+			"Not a TDD Band: 1, subframe assignment and special subframe pattern must not be set",
+			"Invalid PCI: 504, must be between 0 and 503",
+			"Invalid TAC: 0 is reserved",
+		},
+		errorStrings(errs),
+	)
+
+	errs = utils.ValidateCellularRFConfig(utils.RFConfig{
+		Earfcndl:               44590,
+		BandwidthMHz:           7,
+		PCI:                    -1,
+		TAC:                    1,
+		SubframeAssignment:     9,
+		SpecialSubframePattern: 12,
+	})
+	assert.Equal(
+		t,
+		[]string{
+			"Invalid bandwidth 7 MHz: unsupported channel bandwidth, must be one of 3, 5, 10, 15, 20",
+			"Invalid subframe assignment: 9, must be between 0 and 6",
+			"Invalid special subframe pattern: 12, must be between 0 and 9",
+			"Invalid PCI: -1, must be between 0 and 503",
+		},
+		errorStrings(errs),
+	)
+
+	errs = utils.ValidateCellularRFConfig(utils.RFConfig{
+		Earfcndl:     45590,
+		BandwidthMHz: 0,
+		PCI:          1,
+		TAC:          1,
+	})
+	assert.Equal(
+		t,
+		[]string{
+			"Invalid EARFCNDL: no matching band",
+			"Invalid bandwidth 0 MHz: unsupported channel bandwidth, must be one of 3, 5, 10, 15, 20",
+		},
+		errorStrings(errs),
+	)
+
+	errs = utils.ValidateCellularRFConfig(utils.RFConfig{
+		Earfcndl:     10120,
+		BandwidthMHz: 10,
+		PCI:          1,
+		TAC:          1,
+	})
+	assert.Equal(
+		t,
+		[]string{"Not a FDD or TDD Band: 32 is a supplemental downlink (SDL) band"},
+		errorStrings(errs),
+	)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import "fmt"
+
+const (
+	// maxSubframeAssignment is the highest TDD uplink-downlink configuration
+	// (3GPP TS 36.211 Table 4.2-2)
+	maxSubframeAssignment = 6
+	// maxSpecialSubframePattern is the highest special subframe configuration
+	// (3GPP TS 36.211 Table 4.2-1)
+	maxSpecialSubframePattern = 9
+)
+
+// ValidateTDDConfig checks that an EARFCN-DL belongs to a TDD band and that
+// the subframe assignment and special subframe pattern are in range.
+func ValidateTDDConfig(earfcndl, subframeAssignment, specialSubframePattern int32) error {
+	band, err := GetBand(earfcndl)
+	if err != nil {
+		return err
+	}
+	if band.Mode != TDDMode {
+		return fmt.Errorf("Not a TDD Band: %d, subframe assignment and special subframe pattern do not apply", band.ID)
+	}
+	if err := validateSubframeAssignment(subframeAssignment); err != nil {
+		return err
+	}
+	return validateSpecialSubframePattern(specialSubframePattern)
+}
+
+func validateSubframeAssignment(subframeAssignment int32) error {
+	if subframeAssignment < 0 || subframeAssignment > maxSubframeAssignment {
+		return fmt.Errorf("Invalid subframe assignment: %d, must be between 0 and %d", subframeAssignment, maxSubframeAssignment)
+	}
+	return nil
+}
+
+func validateSpecialSubframePattern(specialSubframePattern int32) error {
+	if specialSubframePattern < 0 || specialSubframePattern > maxSpecialSubframePattern {
+		return fmt.Errorf("Invalid special subframe pattern: %d, must be between 0 and %d", specialSubframePattern, maxSpecialSubframePattern)
+	}
+	return nil
+}
+
+// tddSubframePatterns lists the subframes of a radio frame for each
+// uplink-downlink configuration (3GPP TS 36.211 Table 4.2-2), D for
+// downlink, U for uplink and S for special subframes
+var tddSubframePatterns = [maxSubframeAssignment + 1]string{
+	"DSUUUDSUUU",
+	"DSUUDDSUUD",
+	"DSUDDDSUDD",
+	"DSUUUDDDDD",
+	"DSUUDDDDDD",
+	"DSUDDDDDDD",
+	"DSUUUDSUUD",
+}
+
+// TDDSubframeSplit returns the number of downlink, uplink and special
+// subframes per 10 ms radio frame for a subframe assignment
+func TDDSubframeSplit(subframeAssignment int32) (dlSubframes int32, ulSubframes int32, specialSubframes int32, err error) {
+	if err := validateSubframeAssignment(subframeAssignment); err != nil {
+		return 0, 0, 0, err
+	}
+	for _, subframe := range tddSubframePatterns[subframeAssignment] {
+		switch subframe {
+		case 'D':
+			dlSubframes++
+		case 'U':
+			ulSubframes++
+		case 'S':
+			specialSubframes++
+		}
+	}
+	return dlSubframes, ulSubframes, specialSubframes, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTDDConfig(t *testing.T) {
+	assert.NoError(t, utils.ValidateTDDConfig(38650, 0, 0))
+	assert.NoError(t, utils.ValidateTDDConfig(44590, 2, 7))
+	assert.NoError(t, utils.ValidateTDDConfig(55240, 6, 9))
+
+	// FDD band
+	assert.EqualError(
+		t,
+		utils.ValidateTDDConfig(0, 2, 7),
+		"Not a TDD Band: 1, subframe assignment and special subframe pattern do not apply",
+	)
+	// No band
+	assert.EqualError(t, utils.ValidateTDDConfig(45590, 2, 7), "Invalid EARFCNDL: no matching band")
+	// Out of range parameters
+	assert.EqualError(t, utils.ValidateTDDConfig(44590, 7, 7), "Invalid subframe assignment: 7, must be between 0 and 6")
+	assert.EqualError(t, utils.ValidateTDDConfig(44590, -1, 7), "Invalid subframe assignment: -1, must be between 0 and 6")
+	assert.EqualError(t, utils.ValidateTDDConfig(44590, 2, 10), "Invalid special subframe pattern: 10, must be between 0 and 9")
+	assert.EqualError(t, utils.ValidateTDDConfig(44590, 2, -1), "Invalid special subframe pattern: -1, must be between 0 and 9")
+}
+
+func TestTDDSubframeSplit(t *testing.T) {
+	// Subframe assignment -> DL, UL and special subframes per radio frame
+	expected := map[int32][3]int32{
+		0: {2, 6, 2},
+		1: {4, 4, 2},
+		2: {6, 2, 2},
+		3: {6, 3, 1},
+		4: {7, 2, 1},
+		5: {8, 1, 1},
+		6: {3, 5, 2},
+	}
+
+	for subframeAssignment, splitExpected := range expected {
+		dl, ul, special, err := utils.TDDSubframeSplit(subframeAssignment)
+		assert.NoError(t, err)
+		assert.Equal(t, splitExpected, [3]int32{dl, ul, special}, "Subframe assignment %d", subframeAssignment)
+		assert.Equal(t, int32(10), dl+ul+special)
+	}
+
+	for _, subframeAssignment := range [...]int32{-1, 7} {
+		_, _, _, err := utils.TDDSubframeSplit(subframeAssignment)
+		assert.EqualError(t, err, fmt.Sprintf("Invalid subframe assignment: %d, must be between 0 and 6", subframeAssignment))
+	}
+}
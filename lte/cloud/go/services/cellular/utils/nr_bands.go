@@ -0,0 +1,128 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"math"
+)
+
+// NRBand struct for converting NR-ARFCN to Band
+type NRBand struct {
+	ID   int32
+	Mode DuplexMode
+	// StartFreqDl and EndFreqDl are the inclusive edges of the downlink
+	// range (FDL_low and FDL_high) in MHz
+	StartFreqDl float64
+	EndFreqDl   float64
+}
+
+// nrRasterSegment is one segment of the NR global frequency raster
+// (3GPP TS 38.104 Table 5.4.2.1-1):
+// F_REF = F_REF-Offs + ΔF_Global * (N_REF - N_REF-Offs)
+type nrRasterSegment struct {
+	minNrarfcn    int32
+	maxNrarfcn    int32
+	offsetNrarfcn int32
+	offsetFreqKHz int64
+	stepKHz       int64
+}
+
+// maxNRFreqMHz is the upper edge of the NR global frequency raster
+const maxNRFreqMHz = 100000
+
+var nrRaster = [...]nrRasterSegment{
+	// 0 - 3000 MHz
+	{minNrarfcn: 0, maxNrarfcn: 599999, offsetNrarfcn: 0, offsetFreqKHz: 0, stepKHz: 5},
+	// 3000 - 24250 MHz
+	{minNrarfcn: 600000, maxNrarfcn: 2016666, offsetNrarfcn: 600000, offsetFreqKHz: 3000000, stepKHz: 15},
+	// 24250 - 100000 MHz
+	{minNrarfcn: 2016667, maxNrarfcn: 3279165, offsetNrarfcn: 2016667, offsetFreqKHz: 24250080, stepKHz: 60},
+}
+
+var nrBands = [...]NRBand{
+	// FDDMode
+	{ID: 1, Mode: FDDMode, StartFreqDl: 2110, EndFreqDl: 2170},
+	{ID: 2, Mode: FDDMode, StartFreqDl: 1930, EndFreqDl: 1990},
+	{ID: 3, Mode: FDDMode, StartFreqDl: 1805, EndFreqDl: 1880},
+	{ID: 7, Mode: FDDMode, StartFreqDl: 2620, EndFreqDl: 2690},
+	{ID: 28, Mode: FDDMode, StartFreqDl: 758, EndFreqDl: 803},
+	// TDDMode
+	{ID: 41, Mode: TDDMode, StartFreqDl: 2496, EndFreqDl: 2690},
+	{ID: 78, Mode: TDDMode, StartFreqDl: 3300, EndFreqDl: 3800},
+}
+
+// nrarfcnToFreqKHz converts a NR-ARFCN to its frequency in kHz using the
+// raster segment the NR-ARFCN falls into
+func nrarfcnToFreqKHz(nrarfcn int32) (int64, error) {
+	for _, segment := range nrRaster {
+		if segment.minNrarfcn <= nrarfcn && nrarfcn <= segment.maxNrarfcn {
+			return segment.offsetFreqKHz + segment.stepKHz*int64(nrarfcn-segment.offsetNrarfcn), nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid NR-ARFCN: outside of the global frequency raster")
+}
+
+// NRARFCNToFrequencyMHz converts a NR-ARFCN to its frequency in MHz on the
+// NR global frequency raster
+func NRARFCNToFrequencyMHz(nrarfcn int32) (float64, error) {
+	freqKHz, err := nrarfcnToFreqKHz(nrarfcn)
+	if err != nil {
+		return 0, err
+	}
+	return float64(freqKHz) / 1000, nil
+}
+
+// FrequencyMHzToNRARFCN converts a frequency between 0 and 100000 MHz to the
+// NR-ARFCN of the closest point on the NR global frequency raster. Combined
+// with FrequencyForEARFCNDL, this maps an LTE carrier to the equivalent NR
+// channel.
+func FrequencyMHzToNRARFCN(freqMHz float64) (int32, error) {
+	if math.IsNaN(freqMHz) || freqMHz < 0 || freqMHz > maxNRFreqMHz {
+		return 0, fmt.Errorf("Invalid frequency %.3f MHz: outside of the global frequency raster", freqMHz)
+	}
+
+	freqKHz := freqMHz * 1000
+	var closest int32
+	closestDistance := math.Inf(1)
+	for _, segment := range nrRaster {
+		steps := math.Round((freqKHz - float64(segment.offsetFreqKHz)) / float64(segment.stepKHz))
+		nrarfcn := float64(segment.offsetNrarfcn) + steps
+		nrarfcn = math.Max(float64(segment.minNrarfcn), math.Min(float64(segment.maxNrarfcn), nrarfcn))
+		rasterKHz := float64(segment.offsetFreqKHz) + float64(segment.stepKHz)*(nrarfcn-float64(segment.offsetNrarfcn))
+		if distance := math.Abs(rasterKHz - freqKHz); distance < closestDistance {
+			closest, closestDistance = int32(nrarfcn), distance
+		}
+	}
+	return closest, nil
+}
+
+func mhzToKHz(freqMHz float64) int64 {
+	return int64(math.Round(freqMHz * 1000))
+}
+
+// freqDLInRange checks that a downlink frequency in kHz belongs to a band
+func (band NRBand) freqDLInRange(freqKHz int64) bool {
+	return mhzToKHz(band.StartFreqDl) <= freqKHz && freqKHz <= mhzToKHz(band.EndFreqDl)
+}
+
+// GetNRBand for a NR-ARFCN. Bands with overlapping downlink ranges (e.g. n7
+// and n41) resolve to the lowest band number.
+func GetNRBand(nrarfcn int32) (*NRBand, error) {
+	freqKHz, err := nrarfcnToFreqKHz(nrarfcn)
+	if err != nil {
+		return nil, err
+	}
+	for _, band := range nrBands {
+		if band.freqDLInRange(freqKHz) {
+			return &band, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid NR-ARFCN: %w", ErrNoMatchingBand)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package utils_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"magma/lte/cloud/go/services/cellular/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNRBand(t *testing.T) {
+	expected := map[int32]int32{
+		422000: 1,
+		434000: 1,
+		386000: 2,
+		398000: 2,
+		361000: 3,
+		376000: 3,
+		524000: 7,
+		538000: 7,
+		151600: 28,
+		160600: 28,
+		499200: 41,
+		510000: 41,
+		// 3000 MHz and above use the 15 kHz raster
+		620000: 78,
+		653333: 78,
+	}
+
+	for nrarfcn, bandExpected := range expected {
+		band, err := utils.GetNRBand(nrarfcn)
+		assert.NoError(t, err)
+		assert.Equal(t, bandExpected, band.ID)
+	}
+}
+
+func TestGetNRBandError(t *testing.T) {
+	expectedErr := map[int32]string{
+		-1:      "Invalid NR-ARFCN: outside of the global frequency raster",
+		3279166: "Invalid NR-ARFCN: outside of the global frequency raster",
+		0:       "Invalid NR-ARFCN: no matching band",
+		151599:  "Invalid NR-ARFCN: no matching band",
+		600000:  "Invalid NR-ARFCN: no matching band",
+		619999:  "Invalid NR-ARFCN: no matching band",
+		653334:  "Invalid NR-ARFCN: no matching band",
+		2016667: "Invalid NR-ARFCN: no matching band",
+	}
+
+	for nrarfcn, errExpected := range expectedErr {
+		_, err := utils.GetNRBand(nrarfcn)
+		assert.EqualError(t, err, errExpected)
+	}
+	_, err := utils.GetNRBand(0)
+	assert.True(t, errors.Is(err, utils.ErrNoMatchingBand))
+}
+
+func TestNRARFCNToFrequencyMHz(t *testing.T) {
+	expected := map[int32]float64{
+		0:       0,
+		422000:  2110,
+		599999:  2999.995,
+		600000:  3000,
+		620000:  3300,
+		2016666: 24249.99,
+		2016667: 24250.08,
+		3279165: 99999.96,
+	}
+
+	for nrarfcn, freqExpected := range expected {
+		freq, err := utils.NRARFCNToFrequencyMHz(nrarfcn)
+		assert.NoError(t, err)
+		assert.InDelta(t, freqExpected, freq, 1e-9)
+
+		roundTrip, err := utils.FrequencyMHzToNRARFCN(freq)
+		assert.NoError(t, err)
+		assert.Equal(t, nrarfcn, roundTrip)
+	}
+
+	_, err := utils.NRARFCNToFrequencyMHz(-1)
+	assert.EqualError(t, err, "Invalid NR-ARFCN: outside of the global frequency raster")
+	_, err = utils.NRARFCNToFrequencyMHz(3279166)
+	assert.EqualError(t, err, "Invalid NR-ARFCN: outside of the global frequency raster")
+}
+
+func TestFrequencyMHzToNRARFCN(t *testing.T) {
+	// Frequencies off the raster round trip within half a raster step
+	expected := map[float64]float64{
+		2140.0012: 0.0025,
+		2999.998:  0.0025,
+		3500.007:  0.0075,
+		// Between the last 15 kHz and the first 60 kHz raster point
+		24250.0:    0.01,
+		28000.0123: 0.03,
+	}
+
+	for freq, maxError := range expected {
+		nrarfcn, err := utils.FrequencyMHzToNRARFCN(freq)
+		assert.NoError(t, err)
+		roundTrip, err := utils.NRARFCNToFrequencyMHz(nrarfcn)
+		assert.NoError(t, err)
+		assert.InDelta(t, freq, roundTrip, maxError+1e-9, "%f MHz", freq)
+	}
+
+	// LTE carrier at EARFCNDL 300 (2140 MHz) maps to NR-ARFCN 428000 in n1
+	freq, err := utils.FrequencyForEARFCNDL(300)
+	assert.NoError(t, err)
+	nrarfcn, err := utils.FrequencyMHzToNRARFCN(freq)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(428000), nrarfcn)
+	band, err := utils.GetNRBand(nrarfcn)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), band.ID)
+
+	_, err = utils.FrequencyMHzToNRARFCN(-1)
+	assert.EqualError(t, err, "Invalid frequency -1.000 MHz: outside of the global frequency raster")
+	_, err = utils.FrequencyMHzToNRARFCN(100001)
+	assert.EqualError(t, err, "Invalid frequency 100001.000 MHz: outside of the global frequency raster")
+	_, err = utils.FrequencyMHzToNRARFCN(math.NaN())
+	assert.EqualError(t, err, "Invalid frequency NaN MHz: outside of the global frequency raster")
+	_, err = utils.FrequencyMHzToNRARFCN(math.Inf(1))
+	assert.EqualError(t, err, "Invalid frequency +Inf MHz: outside of the global frequency raster")
+	_, err = utils.FrequencyMHzToNRARFCN(math.Inf(-1))
+	assert.EqualError(t, err, "Invalid frequency -Inf MHz: outside of the global frequency raster")
+}
@@ -9,6 +9,8 @@ LICENSE file in the root directory of this source tree.
 package migration
 
 import (
+	"fmt"
+
 	cellular_config "magma/lte/cloud/go/services/cellular/config"
 	"magma/lte/cloud/go/services/cellular/protos"
 	"magma/lte/cloud/go/services/cellular/utils"
@@ -63,6 +65,9 @@ func convert(ret *protos.NetworkRANConfig, config *protos.NetworkRANConfig) erro
 	if err != nil {
 		return err
 	}
+	if band.Mode == utils.SDLMode {
+		return fmt.Errorf("Not a FDD or TDD Band: %d is a supplemental downlink (SDL) band", band.ID)
+	}
 
 	ret.Earfcndl = config.Earfcndl
 	ret.BandwidthMhz = config.BandwidthMhz